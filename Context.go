@@ -72,6 +72,9 @@ type GIUContext struct {
 	textureLoadingQueue *queue.Queue
 	textureFreeingQueue *queue.Queue
 
+	// frameCaptures are regions of the frame captured after it is rendered.
+	frameCaptures []frameCaptureRequest
+
 	cssStylesheet *CSSStylesheet
 
 	m *sync.Mutex
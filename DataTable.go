@@ -0,0 +1,314 @@
+package giu
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// DataColumnWidget describes a column of DataTableWidget.
+type DataColumnWidget[T any] struct {
+	header             string
+	flags              TableColumnFlags
+	innerWidthOrWeight float32
+	cell               func(row T) Widget
+	compare            func(a, b T) int
+	filter             func(row T, query string) bool
+	text               func(row T) string
+}
+
+// DataColumn creates a new column with a header; cell returns widget displayed in the column for a row.
+func DataColumn[T any](header string, cell func(row T) Widget) *DataColumnWidget[T] {
+	return &DataColumnWidget[T]{
+		header: header,
+		cell:   cell,
+	}
+}
+
+// Flags sets the flags of the column.
+func (c *DataColumnWidget[T]) Flags(flags TableColumnFlags) *DataColumnWidget[T] {
+	c.flags = flags
+	return c
+}
+
+// InnerWidthOrWeight sets the inner width or weight of the column.
+func (c *DataColumnWidget[T]) InnerWidthOrWeight(w float32) *DataColumnWidget[T] {
+	c.innerWidthOrWeight = w
+	return c
+}
+
+// Compare makes the column sortable. compare returns a negative number when a < b,
+// a positive number when a > b and zero otherwise (see cmp.Compare).
+func (c *DataColumnWidget[T]) Compare(compare func(a, b T) int) *DataColumnWidget[T] {
+	c.compare = compare
+	return c
+}
+
+// Filter adds an input field to the filter row of the table.
+// Rows for which filter returns false are hidden. Empty query doesn't filter anything.
+func (c *DataColumnWidget[T]) Filter(filter func(row T, query string) bool) *DataColumnWidget[T] {
+	c.filter = filter
+	return c
+}
+
+type dataTableState[T any] struct {
+	filters   []string
+	sortSpecs []ColumnSortSpec
+	// view contains indices of visible rows in display order.
+	view []int
+	// rows data the view was computed for.
+	first *T
+	count int
+	dirty bool
+	// active is true if the table was clicked last (see tableHasKeyboard).
+	active bool
+}
+
+func (s *dataTableState[T]) Dispose() {
+	// noop
+}
+
+var _ Widget = &DataTableWidget[any]{}
+
+// DataTableWidget is a table displaying rows of any type.
+// Unlike TableWidget, it creates widgets for visible rows only and sorts and filters rows by itself.
+type DataTableWidget[T any] struct {
+	id           ID
+	rows         []T
+	columns      []*DataColumnWidget[T]
+	flags        TableFlags
+	size         imgui.Vec2
+	freezeColumn int
+	refresh      bool
+
+	clipboardCopy bool
+}
+
+// DataTable creates a new DataTableWidget displaying rows.
+// Columns are configured with Columns method.
+func DataTable[T any](rows []T) *DataTableWidget[T] {
+	return &DataTableWidget[T]{
+		id:    GenAutoID("DataTable"),
+		rows:  rows,
+		flags: TableFlagsResizable | TableFlagsBorders | TableFlagsRowBg | TableFlagsScrollY | TableFlagsSortable,
+	}
+}
+
+// ID sets the internal id of table widget.
+func (t *DataTableWidget[T]) ID(id ID) *DataTableWidget[T] {
+	t.id = id
+	return t
+}
+
+// Columns sets columns of the table.
+func (t *DataTableWidget[T]) Columns(cols ...*DataColumnWidget[T]) *DataTableWidget[T] {
+	t.columns = cols
+	return t
+}
+
+// Flags sets the flags of the table. Add TableFlagsSortMulti to allow sorting by
+// several columns (with Shift held).
+func (t *DataTableWidget[T]) Flags(flags TableFlags) *DataTableWidget[T] {
+	t.flags = flags
+	return t
+}
+
+// Size sets the size of the table.
+func (t *DataTableWidget[T]) Size(width, height float32) *DataTableWidget[T] {
+	t.size = imgui.Vec2{X: width, Y: height}
+	return t
+}
+
+// Freeze columns so they stay visible when scrolled horizontally. Header rows are always frozen.
+func (t *DataTableWidget[T]) Freeze(col int) *DataTableWidget[T] {
+	t.freezeColumn = col
+	return t
+}
+
+// Refresh makes the table sort and filter rows again.
+// The table does it by itself when the rows slice is replaced or resized;
+// call Refresh after changing rows in place.
+func (t *DataTableWidget[T]) Refresh() *DataTableWidget[T] {
+	t.refresh = true
+	return t
+}
+
+func (t *DataTableWidget[T]) getState() *dataTableState[T] {
+	state := GetState[dataTableState[T]](Context, t.id)
+	if state == nil {
+		state = &dataTableState[T]{dirty: true}
+		SetState(Context, t.id, state)
+	}
+
+	if len(state.filters) != len(t.columns) {
+		state.filters = make([]string, len(t.columns))
+		state.dirty = true
+	}
+
+	return state
+}
+
+// hasFilters returns true if any column has a filter.
+func (t *DataTableWidget[T]) hasFilters() bool {
+	return slices.ContainsFunc(t.columns, func(c *DataColumnWidget[T]) bool {
+		return c.filter != nil
+	})
+}
+
+// Build implements Widget interface.
+func (t *DataTableWidget[T]) Build() {
+	if len(t.columns) == 0 {
+		return
+	}
+
+	state := t.getState()
+
+	if !imgui.BeginTableV(t.id.String(), int32(len(t.columns)), imgui.TableFlags(t.flags), t.size, 0) {
+		return
+	}
+
+	defer imgui.EndTable()
+
+	filters := t.hasFilters()
+	headerRows := 1
+
+	if filters {
+		headerRows++
+	}
+
+	imgui.TableSetupScrollFreeze(int32(t.freezeColumn), int32(headerRows))
+
+	for i, col := range t.columns {
+		flags := col.flags
+		if col.compare == nil {
+			flags |= TableColumnFlagsNoSort
+		}
+
+		imgui.TableSetupColumnV(Context.PrepareString(col.header), imgui.TableColumnFlags(flags), col.innerWidthOrWeight, imgui.ID(i))
+	}
+
+	imgui.TableHeadersRow()
+
+	if filters {
+		t.buildFilterRow(state)
+	}
+
+	if specs := imgui.TableGetSortSpecs(); specs != nil && specs.SpecsDirty() {
+		state.sortSpecs = tableSortSpecs(specs)
+		state.dirty = true
+
+		specs.SetSpecsDirty(false)
+	}
+
+	t.updateView(state)
+
+	if t.clipboardCopy && tableHasKeyboard(&state.active) && isCopyPressed() {
+		copyRecords(dataTableRecords(t.rows, t.columns, state.view))
+	}
+
+	clipper := imgui.NewListClipper()
+	defer clipper.Destroy()
+
+	clipper.Begin(int32(len(state.view)))
+
+	for clipper.Step() {
+		for i := clipper.DisplayStart(); i < clipper.DisplayEnd(); i++ {
+			index := state.view[i]
+
+			imgui.TableNextRow()
+			imgui.PushIDInt(int32(index))
+
+			for _, col := range t.columns {
+				imgui.TableNextColumn()
+
+				if col.cell != nil {
+					col.cell(t.rows[index]).Build()
+				}
+			}
+
+			imgui.PopID()
+		}
+	}
+
+	clipper.End()
+}
+
+// buildFilterRow builds a row of filter inputs under the header.
+func (t *DataTableWidget[T]) buildFilterRow(state *dataTableState[T]) {
+	imgui.TableNextRowV(imgui.TableRowFlagsHeaders, 0)
+
+	for i, col := range t.columns {
+		imgui.TableNextColumn()
+
+		if col.filter == nil {
+			continue
+		}
+
+		InputText(&state.filters[i]).
+			ID(ID(fmt.Sprintf("##%s_filter%d", t.id, i))).
+			Hint("Filter").
+			Size(-1).
+			OnChange(func() {
+				state.dirty = true
+			}).
+			Build()
+	}
+}
+
+// updateView computes visible rows again if the data, filters or sorting changed.
+func (t *DataTableWidget[T]) updateView(state *dataTableState[T]) {
+	var first *T
+	if len(t.rows) > 0 {
+		first = &t.rows[0]
+	}
+
+	if !t.refresh && !state.dirty && state.first == first && state.count == len(t.rows) {
+		return
+	}
+
+	state.view = dataTableView(t.rows, t.columns, state.filters, state.sortSpecs)
+	state.first, state.count = first, len(t.rows)
+	state.dirty = false
+}
+
+// dataTableView returns indices of rows matching filters, ordered according to sortSpecs.
+func dataTableView[T any](rows []T, columns []*DataColumnWidget[T], filters []string, sortSpecs []ColumnSortSpec) []int {
+	view := make([]int, 0, len(rows))
+
+next:
+	for i, row := range rows {
+		for c, col := range columns {
+			if col.filter != nil && c < len(filters) && filters[c] != "" && !col.filter(row, filters[c]) {
+				continue next
+			}
+		}
+
+		view = append(view, i)
+	}
+
+	if len(sortSpecs) == 0 {
+		return view
+	}
+
+	slices.SortStableFunc(view, func(a, b int) int {
+		for _, spec := range sortSpecs {
+			if spec.ColumnIndex >= len(columns) || columns[spec.ColumnIndex].compare == nil {
+				continue
+			}
+
+			result := columns[spec.ColumnIndex].compare(rows[a], rows[b])
+			if spec.Direction == SortDescending {
+				result = -result
+			}
+
+			if result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	})
+
+	return view
+}
@@ -0,0 +1,63 @@
+package giu
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dataTableTestRow struct {
+	name  string
+	count int
+}
+
+func dataTableTestColumns() []*DataColumnWidget[dataTableTestRow] {
+	return []*DataColumnWidget[dataTableTestRow]{
+		DataColumn("Name", func(r dataTableTestRow) Widget { return Label(r.name) }).
+			Compare(func(a, b dataTableTestRow) int { return strings.Compare(a.name, b.name) }).
+			Filter(func(r dataTableTestRow, query string) bool { return strings.Contains(r.name, query) }),
+		DataColumn("Count", func(r dataTableTestRow) Widget { return Labelf("%d", r.count) }).
+			Compare(func(a, b dataTableTestRow) int { return cmp.Compare(a.count, b.count) }),
+		DataColumn("Note", func(dataTableTestRow) Widget { return Label("") }),
+	}
+}
+
+func Test_dataTableView(t *testing.T) {
+	rows := []dataTableTestRow{
+		{"bolt", 3},
+		{"nut", 1},
+		{"washer", 3},
+		{"bracket", 2},
+	}
+	columns := dataTableTestColumns()
+
+	tests := []struct {
+		name      string
+		filters   []string
+		sortSpecs []ColumnSortSpec
+		expected  []int
+	}{
+		{"unsorted", []string{"", "", ""}, nil, []int{0, 1, 2, 3}},
+		{"filtered", []string{"b", "", ""}, nil, []int{0, 3}},
+		{"by name", nil, []ColumnSortSpec{{ColumnIndex: 0, Direction: SortAscending}}, []int{0, 3, 1, 2}},
+		{
+			"by count desc, then name desc", nil,
+			[]ColumnSortSpec{{ColumnIndex: 1, Direction: SortDescending}, {ColumnIndex: 0, Direction: SortDescending}},
+			[]int{2, 0, 3, 1},
+		},
+		{"not sortable column", nil, []ColumnSortSpec{{ColumnIndex: 2, Direction: SortAscending}}, []int{0, 1, 2, 3}},
+		{
+			"filtered and sorted", []string{"b", "", ""},
+			[]ColumnSortSpec{{ColumnIndex: 1, Direction: SortAscending}},
+			[]int{3, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dataTableView(rows, columns, tt.filters, tt.sortSpecs))
+		})
+	}
+}
@@ -531,3 +531,68 @@ const (
 	// PlotScaleSymLog is a symmetric log scale.
 	PlotScaleSymLog PlotScale = PlotScale(implot.ScaleSymLog)
 )
+
+// PlotDragToolFlags represents implot.DragToolFlags.
+type PlotDragToolFlags implot.DragToolFlags
+
+// plot drag tool flags.
+const (
+	PlotDragToolFlagsNone PlotDragToolFlags = PlotDragToolFlags(implot.DragToolFlagsNone)
+	// PlotDragToolFlagsNoCursors disables changing mouse cursor when hovered/held.
+	PlotDragToolFlagsNoCursors PlotDragToolFlags = PlotDragToolFlags(implot.DragToolFlagsNoCursors)
+	// PlotDragToolFlagsNoFit makes the tool ignored by auto-fitting.
+	PlotDragToolFlagsNoFit PlotDragToolFlags = PlotDragToolFlags(implot.DragToolFlagsNoFit)
+	// PlotDragToolFlagsNoInputs disables dragging (the tool is only displayed).
+	PlotDragToolFlagsNoInputs PlotDragToolFlags = PlotDragToolFlags(implot.DragToolFlagsNoInputs)
+	// PlotDragToolFlagsDelayed delays rendering by one frame (useful for moving the tool together with the data).
+	PlotDragToolFlagsDelayed PlotDragToolFlags = PlotDragToolFlags(implot.DragToolFlagsDelayed)
+)
+
+// PlotSubplotFlags represents implot.SubplotFlags.
+type PlotSubplotFlags implot.SubplotFlags
+
+// plot subplot flags.
+const (
+	PlotSubplotFlagsNone     PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsNone)
+	PlotSubplotFlagsNoTitle  PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsNoTitle)
+	PlotSubplotFlagsNoLegend PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsNoLegend)
+	PlotSubplotFlagsNoMenus  PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsNoMenus)
+	// PlotSubplotFlagsNoResize disables resizing subplots with mouse.
+	PlotSubplotFlagsNoResize PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsNoResize)
+	// PlotSubplotFlagsNoAlign disables aligning plot areas of subplots in the same row/column.
+	PlotSubplotFlagsNoAlign PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsNoAlign)
+	// PlotSubplotFlagsShareItems shows one legend (for all the subplots) on the subplots' title.
+	PlotSubplotFlagsShareItems PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsShareItems)
+	// PlotSubplotFlagsLinkRows links y axes of subplots in the same row.
+	PlotSubplotFlagsLinkRows PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsLinkRows)
+	// PlotSubplotFlagsLinkCols links x axes of subplots in the same column.
+	PlotSubplotFlagsLinkCols PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsLinkCols)
+	// PlotSubplotFlagsLinkAllX links x axes of all the subplots.
+	PlotSubplotFlagsLinkAllX PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsLinkAllX)
+	// PlotSubplotFlagsLinkAllY links y axes of all the subplots.
+	PlotSubplotFlagsLinkAllY PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsLinkAllY)
+	// PlotSubplotFlagsColMajor fills subplots column by column (instead of row by row).
+	PlotSubplotFlagsColMajor PlotSubplotFlags = PlotSubplotFlags(implot.SubplotFlagsColMajor)
+)
+
+// PlotLegendFlags represents implot.LegendFlags.
+type PlotLegendFlags implot.LegendFlags
+
+// plot legend flags.
+const (
+	PlotLegendFlagsNone PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsNone)
+	// PlotLegendFlagsNoButtons disables toggling series by clicking legend entries.
+	PlotLegendFlagsNoButtons PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsNoButtons)
+	// PlotLegendFlagsNoHighlightItem disables highlighting series when their legend entry is hovered.
+	PlotLegendFlagsNoHighlightItem PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsNoHighlightItem)
+	// PlotLegendFlagsNoHighlightAxis disables highlighting axes when their legend entry is hovered.
+	PlotLegendFlagsNoHighlightAxis PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsNoHighlightAxis)
+	// PlotLegendFlagsNoMenus disables legend's context menu.
+	PlotLegendFlagsNoMenus PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsNoMenus)
+	// PlotLegendFlagsOutside places legend outside of the plot area.
+	PlotLegendFlagsOutside PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsOutside)
+	// PlotLegendFlagsHorizontal lists legend entries horizontally.
+	PlotLegendFlagsHorizontal PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsHorizontal)
+	// PlotLegendFlagsSort sorts legend entries alphabetically.
+	PlotLegendFlagsSort PlotLegendFlags = PlotLegendFlags(implot.LegendFlagsSort)
+)
@@ -0,0 +1,472 @@
+package giu
+
+import (
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// FlexDirection is the direction in which FlexWidget places its items.
+type FlexDirection byte
+
+// Flex directions.
+const (
+	FlexRow FlexDirection = iota
+	FlexColumn
+)
+
+// FlexJustify tells how FlexWidget distributes free space between items along its direction.
+type FlexJustify byte
+
+// Justify options.
+const (
+	FlexJustifyStart FlexJustify = iota
+	FlexJustifyEnd
+	FlexJustifyCenter
+	// FlexJustifySpaceBetween puts free space between items.
+	FlexJustifySpaceBetween
+	// FlexJustifySpaceAround puts free space around each item (so there is half of it at both ends).
+	FlexJustifySpaceAround
+	// FlexJustifySpaceEvenly puts the same free space between items and at both ends.
+	FlexJustifySpaceEvenly
+)
+
+// FlexAlign tells how FlexWidget aligns items across its direction.
+type FlexAlign byte
+
+// Align options.
+const (
+	// FlexAlignStretch makes items as high as the line (as wide as the container in FlexColumn).
+	FlexAlignStretch FlexAlign = iota
+	FlexAlignStart
+	FlexAlignEnd
+	FlexAlignCenter
+)
+
+// FlexBasisAuto makes the basis of a flex item the size of its content.
+const FlexBasisAuto float32 = -1
+
+var _ Widget = &FlexItemWidget{}
+
+// FlexItemWidget wraps an item of FlexWidget and tells how it grows and shrinks.
+type FlexItemWidget struct {
+	widget Widget
+	grow   float32
+	shrink float32
+	basis  float32
+}
+
+// FlexItem creates a new FlexItemWidget. By default, the item doesn't grow, shrinks (with factor 1)
+// and its basis is the size of its content.
+func FlexItem(widget Widget) *FlexItemWidget {
+	return &FlexItemWidget{
+		widget: widget,
+		shrink: 1,
+		basis:  FlexBasisAuto,
+	}
+}
+
+// Grow sets how much of the free space the item takes (relatively to other items).
+// Items with Basis(0) share the whole space in ratio of their grow factors.
+func (f *FlexItemWidget) Grow(grow float32) *FlexItemWidget {
+	f.grow = grow
+	return f
+}
+
+// Shrink sets how much the item shrinks (relatively to other items and its basis) when items don't fit.
+func (f *FlexItemWidget) Shrink(shrink float32) *FlexItemWidget {
+	f.shrink = shrink
+	return f
+}
+
+// Basis sets the size of the item along the container direction before growing or shrinking.
+// See FlexBasisAuto.
+func (f *FlexItemWidget) Basis(basis float32) *FlexItemWidget {
+	f.basis = basis
+	return f
+}
+
+// Build implements Widget interface.
+func (f *FlexItemWidget) Build() {
+	f.widget.Build()
+}
+
+var _ Widget = &FlexWidget{}
+
+// FlexWidget is a container placing its items in a row or a column like CSS flexbox.
+// Items are measured when they are built and their sizes are used in the next frame
+// (when a size changes, the layout is updated in the following frame). A size which an item
+// only took from the layout (when it grew, shrank or was stretched) is not used as its basis.
+// Items get the computed width as item width (see PushItemWidth). ChildWidget items of zero size
+// are resized to the computed size; other widgets have to fit by themselves.
+type FlexWidget struct {
+	id        ID
+	direction FlexDirection
+	items     []Widget
+	justify   FlexJustify
+	align     FlexAlign
+	gap       float32
+	wrap      bool
+	width     float32
+	height    float32
+}
+
+// Flex creates a new FlexWidget placing items in direction.
+func Flex(direction FlexDirection) *FlexWidget {
+	return &FlexWidget{
+		id:        GenAutoID("Flex"),
+		direction: direction,
+	}
+}
+
+// ID sets the internal id of the container.
+func (f *FlexWidget) ID(id ID) *FlexWidget {
+	f.id = id
+	return f
+}
+
+// Items sets items of the container. Use FlexItem to set how they grow and shrink.
+func (f *FlexWidget) Items(items ...Widget) *FlexWidget {
+	f.items = items
+	return f
+}
+
+// Justify sets how free space is distributed along the direction.
+func (f *FlexWidget) Justify(justify FlexJustify) *FlexWidget {
+	f.justify = justify
+	return f
+}
+
+// Align sets how items are aligned across the direction.
+func (f *FlexWidget) Align(align FlexAlign) *FlexWidget {
+	f.align = align
+	return f
+}
+
+// Gap sets space between items and lines.
+func (f *FlexWidget) Gap(gap float32) *FlexWidget {
+	f.gap = gap
+	return f
+}
+
+// Wrap makes items which don't fit continue on the next line.
+func (f *FlexWidget) Wrap(b bool) *FlexWidget {
+	f.wrap = b
+	return f
+}
+
+// Size sets size of the container. Zero width (or height in FlexColumn) means the available space;
+// zero height of a FlexRow means the height of its content.
+func (f *FlexWidget) Size(width, height float32) *FlexWidget {
+	f.width, f.height = width, height
+	return f
+}
+
+// flexMaxRelayouts is the number of consecutive frames in which FlexWidget updates
+// the layout because sizes of its items changed.
+const flexMaxRelayouts = 3
+
+type flexState struct {
+	// sizes are natural sizes of items measured in the last frames.
+	sizes []imgui.Vec2
+	// relayouts counts consecutive frames in which the sizes changed.
+	relayouts int
+}
+
+// Dispose implements Disposable interface.
+func (s *flexState) Dispose() {
+	// noop
+}
+
+func (f *FlexWidget) getState() *flexState {
+	state := GetState[flexState](Context, f.id)
+	if state == nil {
+		state = &flexState{}
+		SetState(Context, f.id, state)
+	}
+
+	if len(state.sizes) != len(f.items) {
+		state.sizes = make([]imgui.Vec2, len(f.items))
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (f *FlexWidget) Build() {
+	if len(f.items) == 0 {
+		return
+	}
+
+	state := f.getState()
+	avail := imgui.ContentRegionAvail()
+
+	width, height := f.width, f.height
+	if width == 0 {
+		width = avail.X
+	}
+
+	if height == 0 && f.direction == FlexColumn {
+		height = avail.Y
+	}
+
+	specs := make([]flexSpec, len(f.items))
+	for i, w := range f.items {
+		specs[i] = flexSpec{shrink: 1, main: -1}
+		if item, ok := w.(*FlexItemWidget); ok {
+			specs[i] = flexSpec{grow: item.grow, shrink: item.shrink, main: item.basis}
+		}
+
+		measuredMain, measuredCross := f.axes(state.sizes[i])
+		if specs[i].main < 0 {
+			specs[i].main = measuredMain
+		}
+
+		specs[i].cross = measuredCross
+	}
+
+	mainSize, crossSize := f.axes(imgui.Vec2{X: width, Y: height})
+	rects, size := flexLayout(specs, mainSize, crossSize, f.gap, f.wrap, f.justify, f.align)
+
+	start := imgui.CursorPos()
+	changed := false
+
+	imgui.BeginGroup()
+
+	for i, w := range f.items {
+		if item, ok := w.(*FlexItemWidget); ok {
+			w = item.widget
+		}
+
+		pos := f.fromAxes(rects[i].pos)
+		measuredMain, measuredCross := f.axes(buildBox(w, i, imgui.Vec2{X: start.X + pos.X, Y: start.Y + pos.Y}, f.fromAxes(rects[i].size)))
+		lastMain, lastCross := f.axes(state.sizes[i])
+		natural := f.fromAxes(imgui.Vec2{
+			X: flexNaturalSize(measuredMain, rects[i].size.X, specs[i].main, lastMain),
+			Y: flexNaturalSize(measuredCross, rects[i].size.Y, specs[i].cross, lastCross),
+		})
+
+		changed = changed || sizeChanged(natural, state.sizes[i])
+		state.sizes[i] = natural
+	}
+
+	// make the group as big as the container
+	imgui.SetCursorPos(start)
+	imgui.Dummy(f.fromAxes(size))
+	imgui.EndGroup()
+
+	// sizes depending on the layout could change forever, so the relayout is limited
+	switch {
+	case !changed:
+		state.relayouts = 0
+	case state.relayouts < flexMaxRelayouts:
+		state.relayouts++
+
+		Update()
+	}
+}
+
+// buildBox builds i-th item w of a container at pos (relative to the window) and returns its size.
+// The item is expected to be of the given size: size.X is used as the item width
+// and ChildWidget without size is resized to size (see boxWidget).
+func buildBox(w Widget, i int, pos, size imgui.Vec2) imgui.Vec2 {
+	w = boxWidget(w, size)
+
+	imgui.SetCursorPos(pos)
+	imgui.PushIDInt(int32(i))
+	imgui.BeginGroup()
+	imgui.PushItemWidth(size.X)
+
+	w.Build()
+
+	imgui.PopItemWidth()
+	imgui.EndGroup()
+	imgui.PopID()
+
+	return imgui.ItemRectSize()
+}
+
+// flexNaturalSize returns the natural size of an item along one axis from its measured size.
+// laid is the size computed by the layout from basis. If the layout changed the size and the item
+// took it, the measured size isn't natural and last (the natural size from previous frames) is kept.
+func flexNaturalSize(measured, laid, basis, last float32) float32 {
+	if math.Abs(float64(laid-basis)) > 0.5 && math.Abs(float64(measured-laid)) <= 0.5 {
+		return last
+	}
+
+	return measured
+}
+
+// boxWidget returns w to be built in a box of size. ChildWidget without size is replaced by its copy
+// of the box size, so the caller's widget isn't modified.
+func boxWidget(w Widget, size imgui.Vec2) Widget {
+	child, ok := w.(*ChildWidget)
+	if !ok {
+		return w
+	}
+
+	sized := *child
+
+	if sized.width == 0 && size.X > 0 {
+		sized.width = size.X
+	}
+
+	if sized.height == 0 && size.Y > 0 {
+		sized.height = size.Y
+	}
+
+	return &sized
+}
+
+// sizeChanged returns true if measured size of an item differs from the last one.
+func sizeChanged(a, b imgui.Vec2) bool {
+	return math.Abs(float64(a.X-b.X)) > 0.5 || math.Abs(float64(a.Y-b.Y)) > 0.5
+}
+
+// axes converts v to (main, cross) coordinates.
+func (f *FlexWidget) axes(v imgui.Vec2) (main, cross float32) {
+	if f.direction == FlexColumn {
+		return v.Y, v.X
+	}
+
+	return v.X, v.Y
+}
+
+// fromAxes converts v from (main, cross) coordinates.
+func (f *FlexWidget) fromAxes(v imgui.Vec2) imgui.Vec2 {
+	if f.direction == FlexColumn {
+		return imgui.Vec2{X: v.Y, Y: v.X}
+	}
+
+	return v
+}
+
+// flexSpec describes a flex item for the layout: its grow and shrink factors
+// and its size along (main) and across (cross) the container direction.
+type flexSpec struct {
+	grow, shrink float32
+	main, cross  float32
+}
+
+// flexRect is position and size of an item in (main, cross) coordinates (X is main, Y is cross).
+type flexRect struct {
+	pos, size imgui.Vec2
+}
+
+// flexLayout computes positions and sizes of items in container of size mainSize along its direction.
+// crossSize is the size across the direction (0 means the size of content).
+// It returns rectangles of items and size of the container, all in (main, cross) coordinates.
+func flexLayout(items []flexSpec, mainSize, crossSize, gap float32, wrap bool, justify FlexJustify, align FlexAlign) ([]flexRect, imgui.Vec2) {
+	rects := make([]flexRect, len(items))
+
+	// split items to lines
+	var lines [][]int
+
+	used := float32(0)
+
+	for i, item := range items {
+		if len(lines) == 0 || (wrap && used+gap+item.main > mainSize) {
+			lines = append(lines, []int{i})
+			used = item.main
+
+			continue
+		}
+
+		lines[len(lines)-1] = append(lines[len(lines)-1], i)
+		used += gap + item.main
+	}
+
+	cross := float32(0)
+
+	for l, line := range lines {
+		if l > 0 {
+			cross += gap
+		}
+
+		free := mainSize - gap*float32(len(line)-1)
+
+		var grow, shrink float32
+
+		for _, i := range line {
+			rects[i].size.X = items[i].main
+			free -= items[i].main
+			grow += items[i].grow
+			shrink += items[i].shrink * items[i].main
+		}
+
+		switch {
+		case free > 0 && grow > 0:
+			for _, i := range line {
+				rects[i].size.X += free * items[i].grow / grow
+			}
+
+			free = 0
+		case free < 0 && shrink > 0:
+			// items shrink proportionally to their shrink factor and basis
+			for _, i := range line {
+				rects[i].size.X = max(0, rects[i].size.X+free*items[i].shrink*items[i].main/shrink)
+			}
+
+			free = 0
+		}
+
+		offset, space := flexJustify(justify, max(free, 0), len(line))
+
+		lineCross := float32(0)
+		for _, i := range line {
+			lineCross = max(lineCross, items[i].cross)
+		}
+
+		if !wrap && crossSize > 0 {
+			lineCross = crossSize
+		}
+
+		for _, i := range line {
+			rects[i].pos.X = offset
+			offset += rects[i].size.X + gap + space
+
+			rects[i].size.Y = items[i].cross
+			rects[i].pos.Y = cross
+
+			switch align {
+			case FlexAlignStretch:
+				rects[i].size.Y = lineCross
+			case FlexAlignEnd:
+				rects[i].pos.Y += lineCross - items[i].cross
+			case FlexAlignCenter:
+				rects[i].pos.Y += (lineCross - items[i].cross) / 2
+			case FlexAlignStart:
+				// noop
+			}
+		}
+
+		cross += lineCross
+	}
+
+	return rects, imgui.Vec2{X: mainSize, Y: max(cross, crossSize)}
+}
+
+// flexJustify returns position of the first item and additional space between items
+// when free space is distributed according to justify.
+func flexJustify(justify FlexJustify, free float32, count int) (offset, space float32) {
+	switch justify {
+	case FlexJustifyEnd:
+		return free, 0
+	case FlexJustifyCenter:
+		return free / 2, 0
+	case FlexJustifySpaceBetween:
+		if count < 2 {
+			return 0, 0
+		}
+
+		return 0, free / float32(count-1)
+	case FlexJustifySpaceAround:
+		space = free / float32(count)
+		return space / 2, space
+	case FlexJustifySpaceEvenly:
+		space = free / float32(count+1)
+		return space, space
+	default:
+		return 0, 0
+	}
+}
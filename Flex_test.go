@@ -0,0 +1,123 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flexLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []flexSpec
+		main    float32
+		gap     float32
+		wrap    bool
+		justify FlexJustify
+		align   FlexAlign
+		rects   []flexRect
+		size    imgui.Vec2
+	}{
+		{
+			name:  "grow 1:2:1",
+			items: []flexSpec{{grow: 1, cross: 10}, {grow: 2, cross: 20}, {grow: 1, cross: 10}},
+			main:  420,
+			gap:   10,
+			rects: []flexRect{
+				{pos: imgui.Vec2{X: 0}, size: imgui.Vec2{X: 100, Y: 20}},
+				{pos: imgui.Vec2{X: 110}, size: imgui.Vec2{X: 200, Y: 20}},
+				{pos: imgui.Vec2{X: 320}, size: imgui.Vec2{X: 100, Y: 20}},
+			},
+			size: imgui.Vec2{X: 420, Y: 20},
+		},
+		{
+			name:  "fill remaining space",
+			items: []flexSpec{{main: 100, cross: 20}, {main: 50, grow: 1, cross: 20}},
+			main:  400,
+			rects: []flexRect{
+				{pos: imgui.Vec2{X: 0}, size: imgui.Vec2{X: 100, Y: 20}},
+				{pos: imgui.Vec2{X: 100}, size: imgui.Vec2{X: 300, Y: 20}},
+			},
+			size: imgui.Vec2{X: 400, Y: 20},
+		},
+		{
+			name:  "shrink by basis",
+			items: []flexSpec{{main: 300, shrink: 1, cross: 10}, {main: 100, shrink: 1, cross: 10}},
+			main:  200,
+			rects: []flexRect{
+				{pos: imgui.Vec2{X: 0}, size: imgui.Vec2{X: 150, Y: 10}},
+				{pos: imgui.Vec2{X: 150}, size: imgui.Vec2{X: 50, Y: 10}},
+			},
+			size: imgui.Vec2{X: 200, Y: 10},
+		},
+		{
+			name:    "space between, centered",
+			items:   []flexSpec{{main: 50, cross: 10}, {main: 50, cross: 30}},
+			main:    200,
+			justify: FlexJustifySpaceBetween,
+			align:   FlexAlignCenter,
+			rects: []flexRect{
+				{pos: imgui.Vec2{X: 0, Y: 10}, size: imgui.Vec2{X: 50, Y: 10}},
+				{pos: imgui.Vec2{X: 150, Y: 0}, size: imgui.Vec2{X: 50, Y: 30}},
+			},
+			size: imgui.Vec2{X: 200, Y: 30},
+		},
+		{
+			name:  "wrap",
+			items: []flexSpec{{main: 60, cross: 10}, {main: 60, cross: 20}, {main: 60, cross: 10}},
+			main:  130,
+			gap:   5,
+			wrap:  true,
+			align: FlexAlignStart,
+			rects: []flexRect{
+				{pos: imgui.Vec2{X: 0, Y: 0}, size: imgui.Vec2{X: 60, Y: 10}},
+				{pos: imgui.Vec2{X: 65, Y: 0}, size: imgui.Vec2{X: 60, Y: 20}},
+				{pos: imgui.Vec2{X: 0, Y: 25}, size: imgui.Vec2{X: 60, Y: 10}},
+			},
+			size: imgui.Vec2{X: 130, Y: 35},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rects, size := flexLayout(tt.items, tt.main, 0, tt.gap, tt.wrap, tt.justify, tt.align)
+			assert.Equal(t, tt.rects, rects)
+			assert.Equal(t, tt.size, size)
+		})
+	}
+}
+
+func Test_flexJustify(t *testing.T) {
+	offset, space := flexJustify(FlexJustifySpaceEvenly, 90, 2)
+	assert.InDelta(t, 30, offset, 1e-6)
+	assert.InDelta(t, 30, space, 1e-6)
+
+	offset, space = flexJustify(FlexJustifySpaceAround, 80, 2)
+	assert.InDelta(t, 20, offset, 1e-6)
+	assert.InDelta(t, 40, space, 1e-6)
+
+	offset, _ = flexJustify(FlexJustifyEnd, 80, 2)
+	assert.InDelta(t, 80, offset, 1e-6)
+}
+
+func Test_flexNaturalSize(t *testing.T) {
+	assert.InDelta(t, 50, flexNaturalSize(50, 50, 50, 40), 1e-6, "size of item laid at its basis should be natural")
+	assert.InDelta(t, 40, flexNaturalSize(200, 200, 40, 40), 1e-6, "grown size taken by item shouldn't be used as basis")
+	assert.InDelta(t, 30, flexNaturalSize(30, 200, 40, 40), 1e-6, "item not taking the grown size should be measured")
+	assert.InDelta(t, 40, flexNaturalSize(20, 20, 40, 40), 1e-6, "shrunk size taken by item shouldn't be used as basis")
+}
+
+func Test_boxWidget(t *testing.T) {
+	child := &ChildWidget{}
+	sized, ok := boxWidget(child, imgui.Vec2{X: 100, Y: 50}).(*ChildWidget)
+
+	assert.True(t, ok)
+	assert.InDelta(t, 100, sized.width, 1e-6)
+	assert.InDelta(t, 50, sized.height, 1e-6)
+	assert.Zero(t, child.width, "caller's widget shouldn't be resized")
+	assert.Zero(t, child.height, "caller's widget shouldn't be resized")
+
+	label := &LabelWidget{label: "x"}
+	assert.Same(t, label, boxWidget(label, imgui.Vec2{X: 100}))
+}
@@ -2,6 +2,7 @@ package giu
 
 import (
 	"fmt"
+	"io/fs"
 	"log"
 	"runtime"
 	"unsafe"
@@ -23,6 +24,7 @@ type FontInfo struct {
 	fontName string
 	fontPath string
 	fontByte []byte
+	config   *FontConfig
 }
 
 // String returns a string representation of the FontInfo. It is intended to be unique for each FontInfo.
@@ -30,6 +32,167 @@ func (f *FontInfo) String() string {
 	return f.fontName
 }
 
+// FontStyle describes a style variant of a font inside of a font family.
+// Styles are bit flags, so FontStyleBold|FontStyleItalic == FontStyleBoldItalic.
+type FontStyle byte
+
+// font styles.
+const (
+	FontStyleRegular    FontStyle = 0
+	FontStyleBold       FontStyle = 1 << 0
+	FontStyleItalic     FontStyle = 1 << 1
+	FontStyleBoldItalic           = FontStyleBold | FontStyleItalic
+)
+
+// String returns a human-readable name of the style.
+func (s FontStyle) String() string {
+	switch s {
+	case FontStyleRegular:
+		return "Regular"
+	case FontStyleBold:
+		return "Bold"
+	case FontStyleItalic:
+		return "Italic"
+	case FontStyleBoldItalic:
+		return "BoldItalic"
+	}
+
+	return fmt.Sprintf("FontStyle(%d)", byte(s))
+}
+
+// FontConfig describes how a font is rasterized and which fonts
+// should supply glyphs that the font itself does not contain.
+// Fallback fonts are merged into the font in the order they were added,
+// so e.g. CJK, Cyrillic or emoji glyphs missing in the main font are
+// taken from the first fallback that provides them.
+// Zero values of the numeric fields mean "use imgui's default".
+type FontConfig struct {
+	oversampleH, oversampleV int
+	pixelSnapH, pixelSnapV   bool
+	glyphOffsetX             float32
+	glyphOffsetY             float32
+	glyphExtraAdvanceX       float32
+	glyphMinAdvanceX         float32
+	rasterizerMultiply       float32
+	rasterizerDensity        float32
+	fallbacks                []FontInfo
+}
+
+// NewFontConfig creates a new FontConfig with imgui's defaults.
+func NewFontConfig() *FontConfig {
+	return &FontConfig{}
+}
+
+// defaultFontConfig returns the config used for default fonts
+// unless user specifies another one.
+func defaultFontConfig() *FontConfig {
+	return NewFontConfig().Oversample(2, 2).RasterizerMultiply(1.5)
+}
+
+// Oversample sets horizontal and vertical oversampling.
+// Higher values give better quality of scaled/subpixel positioned glyphs at the cost of memory.
+func (c *FontConfig) Oversample(h, v int) *FontConfig {
+	c.oversampleH, c.oversampleV = h, v
+	return c
+}
+
+// PixelSnap aligns glyphs to the pixel grid.
+// Useful for pixel-perfect fonts.
+func (c *FontConfig) PixelSnap(h, v bool) *FontConfig {
+	c.pixelSnapH, c.pixelSnapV = h, v
+	return c
+}
+
+// GlyphSpacing sets extra horizontal space added between glyphs (in pixels).
+func (c *FontConfig) GlyphSpacing(x float32) *FontConfig {
+	c.glyphExtraAdvanceX = x
+	return c
+}
+
+// GlyphMinAdvance sets the minimal horizontal advance of each glyph.
+// Useful to make icon fonts monospaced.
+func (c *FontConfig) GlyphMinAdvance(x float32) *FontConfig {
+	c.glyphMinAdvanceX = x
+	return c
+}
+
+// GlyphOffset offsets all glyphs of this font.
+// It is useful to vertically align fallback fonts with the main one.
+func (c *FontConfig) GlyphOffset(x, y float32) *FontConfig {
+	c.glyphOffsetX, c.glyphOffsetY = x, y
+	return c
+}
+
+// RasterizerMultiply brightens (>1) or darkens (<1) the font output.
+func (c *FontConfig) RasterizerMultiply(m float32) *FontConfig {
+	c.rasterizerMultiply = m
+	return c
+}
+
+// RasterizerDensity sets DPI scale for rasterization.
+func (c *FontConfig) RasterizerDensity(d float32) *FontConfig {
+	c.rasterizerDensity = d
+	return c
+}
+
+// AddFallback appends a system font (found by name) to the fallback chain.
+// If the font cannot be found, a warning is printed and the chain is not changed.
+func (c *FontConfig) AddFallback(fontName string) *FontConfig {
+	fontPath, err := findfont.Find(fontName)
+	if err != nil {
+		fmt.Printf("[Warning]Cannot find fallback font %s at system.\n", fontName)
+		return c
+	}
+
+	c.fallbacks = append(c.fallbacks, FontInfo{fontName: fontName, fontPath: fontPath})
+
+	return c
+}
+
+// AddFallbackFromBytes appends font data from memory to the fallback chain.
+func (c *FontConfig) AddFallbackFromBytes(fontName string, fontBytes []byte) *FontConfig {
+	c.fallbacks = append(c.fallbacks, FontInfo{fontName: fontName, fontByte: fontBytes})
+	return c
+}
+
+// Fallbacks returns the fallback chain in order.
+func (c *FontConfig) Fallbacks() []FontInfo {
+	return c.fallbacks
+}
+
+// toImgui converts FontConfig to imgui.FontConfig.
+func (c *FontConfig) toImgui() *imgui.FontConfig {
+	result := imgui.NewFontConfig()
+
+	if c == nil {
+		return result
+	}
+
+	if c.oversampleH > 0 {
+		result.SetOversampleH(c.oversampleH)
+	}
+
+	if c.oversampleV > 0 {
+		result.SetOversampleV(c.oversampleV)
+	}
+
+	result.SetPixelSnapH(c.pixelSnapH)
+	result.SetPixelSnapV(c.pixelSnapV)
+	result.SetGlyphOffset(imgui.Vec2{X: c.glyphOffsetX, Y: c.glyphOffsetY})
+	result.SetGlyphExtraAdvanceX(c.glyphExtraAdvanceX)
+	result.SetGlyphMinAdvanceX(c.glyphMinAdvanceX)
+
+	if c.rasterizerMultiply > 0 {
+		result.SetRasterizerMultiply(c.rasterizerMultiply)
+	}
+
+	if c.rasterizerDensity > 0 {
+		result.SetRasterizerDensity(c.rasterizerDensity)
+	}
+
+	return result
+}
+
 // FontAtlas is a mechanism to automatically manage fonts in giu.
 // When you add a string in your app, it is registered inside the FontAtlas.
 // Then, font data are built based on the registered strings.
@@ -41,11 +204,14 @@ type FontAtlas struct {
 	defaultFonts           []FontInfo
 	extraFonts             []FontInfo
 	extraFontMap           map[string]*imgui.Font
+	fontFamilies           map[string]map[FontStyle]*FontInfo
+	defaultFontFamily      string
 }
 
 func newFontAtlas() *FontAtlas {
 	result := FontAtlas{
 		extraFontMap: make(map[string]*imgui.Font),
+		fontFamilies: make(map[string]map[FontStyle]*FontInfo),
 	}
 
 	result.SetDefaultFontSize(DefaultFontSize)
@@ -104,21 +270,34 @@ func (a *FontAtlas) SetDefaultFontSize(size float32) {
 
 // SetDefaultFont changes default font.
 func (a *FontAtlas) SetDefaultFont(fontName string) {
+	a.SetDefaultFontV(fontName, nil)
+}
+
+// SetDefaultFontV does similar to SetDefaultFont but allows to specify FontConfig.
+// If config is nil, the default config is used.
+func (a *FontAtlas) SetDefaultFontV(fontName string, config *FontConfig) {
 	fontPath, err := findfont.Find(fontName)
 	if err != nil {
 		log.Fatalf("Cannot find font %s", fontName)
 		return
 	}
 
-	fontInfo := FontInfo{fontName: fontName, fontPath: fontPath}
+	fontInfo := FontInfo{fontName: fontName, fontPath: fontPath, config: config}
 	a.defaultFonts = append([]FontInfo{fontInfo}, a.defaultFonts...)
 }
 
 // SetDefaultFontFromBytes changes default font by bytes of the font file.
 func (a *FontAtlas) SetDefaultFontFromBytes(fontBytes []byte) {
+	a.SetDefaultFontFromBytesV(fontBytes, nil)
+}
+
+// SetDefaultFontFromBytesV does similar to SetDefaultFontFromBytes but allows to specify FontConfig.
+// If config is nil, the default config is used.
+func (a *FontAtlas) SetDefaultFontFromBytesV(fontBytes []byte, config *FontConfig) {
 	a.defaultFonts = append([]FontInfo{
 		{
 			fontByte: fontBytes,
+			config:   config,
 		},
 	}, a.defaultFonts...)
 }
@@ -131,6 +310,11 @@ func (a *FontAtlas) GetDefaultFonts() []FontInfo {
 // AddFont adds font by name, if the font is found, return *FontInfo, otherwise return nil.
 // To use added font, use giu.Style().SetFont(...).
 func (a *FontAtlas) AddFont(fontName string) *FontInfo {
+	return a.AddFontV(fontName, nil)
+}
+
+// AddFontV does similar to AddFont but allows to specify FontConfig.
+func (a *FontAtlas) AddFontV(fontName string, config *FontConfig) *FontInfo {
 	fontPath, err := findfont.Find(fontName)
 	if err != nil {
 		fmt.Printf("[Warning]Cannot find font %s at system, related text will not be rendered.\n", fontName)
@@ -140,6 +324,7 @@ func (a *FontAtlas) AddFont(fontName string) *FontInfo {
 	fi := FontInfo{
 		fontName: fontName,
 		fontPath: fontPath,
+		config:   config,
 	}
 
 	a.extraFonts = append(a.extraFonts, fi)
@@ -149,9 +334,15 @@ func (a *FontAtlas) AddFont(fontName string) *FontInfo {
 
 // AddFontFromBytes does similar to AddFont, but using data from memory.
 func (a *FontAtlas) AddFontFromBytes(fontName string, fontBytes []byte) *FontInfo {
+	return a.AddFontFromBytesV(fontName, fontBytes, nil)
+}
+
+// AddFontFromBytesV does similar to AddFontFromBytes but allows to specify FontConfig.
+func (a *FontAtlas) AddFontFromBytesV(fontName string, fontBytes []byte, config *FontConfig) *FontInfo {
 	fi := FontInfo{
 		fontName: fontName,
 		fontByte: fontBytes,
+		config:   config,
 	}
 
 	a.extraFonts = append(a.extraFonts, fi)
@@ -159,6 +350,95 @@ func (a *FontAtlas) AddFontFromBytes(fontName string, fontBytes []byte) *FontInf
 	return &fi
 }
 
+// AddFontFromFS does similar to AddFontFromBytes, but reads font data from fsys.
+// This allows to use fonts embedded with embed.FS.
+func (a *FontAtlas) AddFontFromFS(fsys fs.FS, fontName, fontPath string) (*FontInfo, error) {
+	fontBytes, err := fs.ReadFile(fsys, fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("AddFontFromFS: error reading font file %s: %w", fontPath, err)
+	}
+
+	return a.AddFontFromBytes(fontName, fontBytes), nil
+}
+
+// AddFontFamilyFS registers a font family. styles maps each available style to
+// a font file path inside of fsys.
+// Fonts of the family can be used with (*LabelWidget).FontFamily/Bold/Italic or
+// the same methods of StyleSetter.
+// The first registered family becomes the default one (see SetDefaultFontFamily).
+// If any of the files cannot be read, nothing is registered.
+func (a *FontAtlas) AddFontFamilyFS(fsys fs.FS, family string, styles map[FontStyle]string) error {
+	data := make(map[FontStyle][]byte, len(styles))
+
+	for style, fontPath := range styles {
+		fontBytes, err := fs.ReadFile(fsys, fontPath)
+		if err != nil {
+			return fmt.Errorf("AddFontFamilyFS: error reading %s font of %s (%s): %w", style, family, fontPath, err)
+		}
+
+		data[style] = fontBytes
+	}
+
+	fonts, ok := a.fontFamilies[family]
+	if !ok {
+		fonts = make(map[FontStyle]*FontInfo)
+		a.fontFamilies[family] = fonts
+	}
+
+	for style, fontBytes := range data {
+		fonts[style] = a.AddFontFromBytes(fmt.Sprintf("%s %s", family, style), fontBytes)
+	}
+
+	if a.defaultFontFamily == "" {
+		a.defaultFontFamily = family
+	}
+
+	return nil
+}
+
+// SetDefaultFontFamily sets a family used when only style (e.g. Bold()) is specified.
+func (a *FontAtlas) SetDefaultFontFamily(family string) {
+	a.defaultFontFamily = family
+}
+
+// GetFontFamily returns a font of the family in the given style.
+// If the family doesn't provide this style, the closest one is returned
+// (BoldItalic falls back to Bold, then Italic and finally to Regular).
+// Empty family means the default family.
+// It returns nil if the family is not registered.
+func (a *FontAtlas) GetFontFamily(family string, style FontStyle) *FontInfo {
+	if family == "" {
+		family = a.defaultFontFamily
+	}
+
+	fonts, ok := a.fontFamilies[family]
+	if !ok {
+		return nil
+	}
+
+	for _, s := range []FontStyle{style, style &^ FontStyleItalic, style &^ FontStyleBold, FontStyleRegular} {
+		if f, ok := fonts[s]; ok {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// resolveFont returns font that should be pushed for a widget
+// with the specified font, font family and style.
+func (a *FontAtlas) resolveFont(font *FontInfo, family string, style FontStyle) *FontInfo {
+	if family == "" && style == FontStyleRegular {
+		return font
+	}
+
+	if f := a.GetFontFamily(family, style); f != nil {
+		return f
+	}
+
+	return font
+}
+
 func (a *FontAtlas) registerDefaultFont(fontName string) {
 	fontPath, err := findfont.Find(fontName)
 	if err != nil {
@@ -196,33 +476,14 @@ func (a *FontAtlas) rebuildFontAtlas() {
 	fonts.Clear()
 
 	if len(a.defaultFonts) > 0 {
-		fontConfig := imgui.NewFontConfig()
-		fontConfig.SetOversampleH(2)
-		fontConfig.SetOversampleV(2)
-		fontConfig.SetRasterizerMultiply(1.5)
-
 		for i, fontInfo := range a.defaultFonts {
-			if i > 0 {
-				fontConfig.SetMergeMode(true)
+			config := fontInfo.config
+			if config == nil {
+				config = defaultFontConfig()
 			}
 
-			if len(fontInfo.fontByte) == 0 {
-				fonts.AddFontFromFileTTFV(
-					fontInfo.fontPath,
-					0,
-					fontConfig,
-					nil,
-				)
-			} else {
-				fontConfig.SetFontDataOwnedByAtlas(false)
-				fonts.AddFontFromMemoryTTFV(
-					uintptr(unsafe.Pointer(utils.SliceToPtr(fontInfo.fontByte))),
-					int32(len(fontInfo.fontByte)),
-					0,
-					fontConfig,
-					nil,
-				)
-			}
+			// every default font after the first one is merged into it.
+			a.addFont(fonts, fontInfo, config, i > 0)
 		}
 
 		// Fall back if no font is added
@@ -236,28 +497,53 @@ func (a *FontAtlas) rebuildFontAtlas() {
 	// Add extra fonts
 	for _, fontInfo := range a.extraFonts {
 		// Store imgui.Font for PushFont
-		var f *imgui.Font
-		if len(fontInfo.fontByte) == 0 {
-			f = fonts.AddFontFromFileTTFV(
-				fontInfo.fontPath,
-				0,
-				imgui.NewFontConfig(),
-				nil,
-			)
-		} else {
-			fontConfig := imgui.NewFontConfig()
-			fontConfig.SetFontDataOwnedByAtlas(false)
-			f = fonts.AddFontFromMemoryTTFV(
-				uintptr(unsafe.Pointer(utils.SliceToPtr(fontInfo.fontByte))),
-				int32(len(fontInfo.fontByte)),
-				0,
-				fontConfig,
-				nil,
-			)
+		a.extraFontMap[fontInfo.String()] = a.addFont(fonts, fontInfo, fontInfo.config, false)
+	}
+
+	a.shouldRebuildFontAtlas = false
+}
+
+// addFont adds fontInfo to the imgui's font atlas and merges its fallback chain into it.
+// It returns the font that fallbacks were merged into.
+func (a *FontAtlas) addFont(fonts *imgui.FontAtlas, fontInfo FontInfo, config *FontConfig, merge bool) *imgui.Font {
+	f := a.addFontData(fonts, fontInfo, config, merge)
+
+	if config == nil {
+		return f
+	}
+
+	for _, fallback := range config.fallbacks {
+		fallbackConfig := fallback.config
+		if fallbackConfig == nil {
+			fallbackConfig = config
 		}
 
-		a.extraFontMap[fontInfo.String()] = f
+		a.addFontData(fonts, fallback, fallbackConfig, true)
 	}
 
-	a.shouldRebuildFontAtlas = false
+	return f
+}
+
+func (a *FontAtlas) addFontData(fonts *imgui.FontAtlas, fontInfo FontInfo, config *FontConfig, merge bool) *imgui.Font {
+	fontConfig := config.toImgui()
+	fontConfig.SetMergeMode(merge)
+
+	if len(fontInfo.fontByte) == 0 {
+		return fonts.AddFontFromFileTTFV(
+			fontInfo.fontPath,
+			0,
+			fontConfig,
+			nil,
+		)
+	}
+
+	fontConfig.SetFontDataOwnedByAtlas(false)
+
+	return fonts.AddFontFromMemoryTTFV(
+		uintptr(unsafe.Pointer(utils.SliceToPtr(fontInfo.fontByte))),
+		int32(len(fontInfo.fontByte)),
+		0,
+		fontConfig,
+		nil,
+	)
 }
@@ -0,0 +1,58 @@
+package giu
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFontAtlas_GetFontFamily(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fonts/Sans-Regular.ttf": {Data: []byte("regular")},
+		"fonts/Sans-Bold.ttf":    {Data: []byte("bold")},
+		"fonts/Serif-Italic.ttf": {Data: []byte("italic")},
+	}
+
+	a := &FontAtlas{fontFamilies: make(map[string]map[FontStyle]*FontInfo)}
+
+	require.NoError(t, a.AddFontFamilyFS(fsys, "Sans", map[FontStyle]string{
+		FontStyleRegular: "fonts/Sans-Regular.ttf",
+		FontStyleBold:    "fonts/Sans-Bold.ttf",
+	}))
+	require.NoError(t, a.AddFontFamilyFS(fsys, "Serif", map[FontStyle]string{
+		FontStyleItalic: "fonts/Serif-Italic.ttf",
+	}))
+	require.Error(t, a.AddFontFamilyFS(fsys, "Mono", map[FontStyle]string{
+		FontStyleRegular: "fonts/missing.ttf",
+	}))
+
+	cases := []struct {
+		name     string
+		family   string
+		style    FontStyle
+		expected string
+	}{
+		{"exact match", "Sans", FontStyleBold, "Sans Bold"},
+		{"default family", "", FontStyleRegular, "Sans Regular"},
+		{"bold italic falls back to bold", "Sans", FontStyleBoldItalic, "Sans Bold"},
+		{"italic falls back to regular", "Sans", FontStyleItalic, "Sans Regular"},
+		{"bold italic falls back to italic", "Serif", FontStyleBoldItalic, "Serif Italic"},
+		{"missing style", "Serif", FontStyleRegular, ""},
+		{"not registered family", "Mono", FontStyleRegular, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := a.GetFontFamily(c.family, c.style)
+			if c.expected == "" {
+				assert.Nil(t, f)
+				return
+			}
+
+			require.NotNil(t, f)
+			assert.Equal(t, c.expected, f.String())
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package giu
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ErrFrameNotCaptured is returned when a region of the rendered frame can't be captured.
+var ErrFrameNotCaptured = errors.New("frame could not be captured")
+
+// frameCaptureRequest is a request to capture a rectangle of a viewport after the frame is rendered.
+type frameCaptureRequest struct {
+	viewport  imgui.ID
+	min, max  imgui.Vec2
+	onCapture func(img *image.NRGBA, err error)
+}
+
+// captureFrame requests a capture of the rectangle min-max (in screen coordinates) of the current
+// window's viewport. The rectangle is captured when the current frame is rendered and onCapture
+// is called on the rendering goroutine.
+func captureFrame(rectMin, rectMax imgui.Vec2, onCapture func(img *image.NRGBA, err error)) {
+	Context.frameCaptures = append(Context.frameCaptures, frameCaptureRequest{
+		viewport:  imgui.WindowViewport().ID(),
+		min:       rectMin,
+		max:       rectMax,
+		onCapture: onCapture,
+	})
+}
+
+// captureFrames handles capture requests of the rendered frame. It must be called after the frame
+// is rendered (draw data are valid until the next frame starts). background is the clear color
+// of the master window.
+func (c *GIUContext) captureFrames(background imgui.Vec4) {
+	requests := c.frameCaptures
+	c.frameCaptures = nil
+
+	for _, r := range requests {
+		data := imgui.CurrentDrawData()
+		if viewport := imgui.FindViewportByID(r.viewport); viewport != nil && viewport.CData != nil {
+			data = viewport.DrawData()
+		}
+
+		if data == nil || data.CData == nil || !data.Valid() {
+			r.onCapture(nil, ErrFrameNotCaptured)
+			continue
+		}
+
+		triangles, err := readDrawData(data, r.min, r.max)
+		if err != nil {
+			r.onCapture(nil, err)
+			continue
+		}
+
+		scale := data.FramebufferScale()
+		width := int(math.Ceil(float64((r.max.X - r.min.X) * scale.X)))
+		height := int(math.Ceil(float64((r.max.Y - r.min.Y) * scale.Y)))
+
+		img := image.NewNRGBA(image.Rect(0, 0, max(width, 0), max(height, 0)))
+		rasterizeTriangles(img, r.min, scale, background, triangles)
+
+		r.onCapture(img, nil)
+	}
+}
+
+// captureVertex has the same memory layout as ImDrawVert.
+type captureVertex struct {
+	pos, uv imgui.Vec2
+	col     uint32
+}
+
+// captureTexture is a texture used by captured triangles. Pixels are RGBA32 or Alpha8 (one byte per pixel).
+type captureTexture struct {
+	width, height int
+	pixels        []byte
+	alpha8        bool
+}
+
+// captureTriangles are triangles of a draw command (3 vertices per triangle) drawn with texture
+// and clipped to clip rectangle (min x, min y, max x, max y in screen coordinates).
+type captureTriangles struct {
+	clip     imgui.Vec4
+	texture  *captureTexture
+	vertices []captureVertex
+}
+
+// readDrawData copies triangles of draw data intersecting the rectangle min-max.
+// Commands drawing user textures (e.g. images) are skipped as their pixels are not available.
+func readDrawData(data *imgui.DrawData, rectMin, rectMax imgui.Vec2) ([]captureTriangles, error) {
+	var result []captureTriangles
+
+	textures := make(map[unsafe.Pointer]*captureTexture)
+
+	// CmdLists is a vector of pointers to draw lists
+	cmdLists := data.CmdLists()
+	if cmdLists.Size == 0 {
+		return nil, nil
+	}
+
+	lists := unsafe.Slice((*unsafe.Pointer)(unsafe.Pointer(cmdLists.Data.CData)), cmdLists.Size)
+
+	for _, l := range lists {
+		list := imgui.NewDrawListFromC(l)
+
+		vtxBuffer := list.VtxBuffer()
+		if vtxBuffer.Size == 0 {
+			continue
+		}
+
+		if unsafe.Sizeof(*vtxBuffer.Data.CData) != unsafe.Sizeof(captureVertex{}) {
+			return nil, ErrFrameNotCaptured
+		}
+
+		vertices := unsafe.Slice((*captureVertex)(unsafe.Pointer(vtxBuffer.Data.CData)), vtxBuffer.Size)
+		indices := list.IdxBuffer().Slice()
+
+		cmdBuffer := list.CmdBuffer()
+		first := unsafe.Pointer(cmdBuffer.Data.CData)
+		size := unsafe.Sizeof(*cmdBuffer.Data.CData)
+
+		for i := range cmdBuffer.Size {
+			cmd := imgui.NewDrawCmdFromC(unsafe.Add(first, uintptr(i)*size))
+
+			// commands with callbacks draw nothing
+			count := int(cmd.ElemCount())
+			if count == 0 {
+				continue
+			}
+
+			clip := cmd.ClipRect()
+			if clip.Z <= rectMin.X || clip.X >= rectMax.X || clip.W <= rectMin.Y || clip.Y >= rectMax.Y {
+				continue
+			}
+
+			texRef := cmd.TexRef()
+
+			texture := readTexture(texRef.TexData(), textures)
+			if texture == nil {
+				continue
+			}
+
+			triangles := captureTriangles{clip: clip, texture: texture, vertices: make([]captureVertex, count)}
+			offset, vtxOffset := int(cmd.IdxOffset()), int(cmd.VtxOffset())
+
+			for j, index := range indices[offset : offset+count] {
+				triangles.vertices[j] = vertices[vtxOffset+int(index)]
+			}
+
+			result = append(result, triangles)
+		}
+	}
+
+	return result, nil
+}
+
+// readTexture returns pixels of texture data (cached in textures) or nil if the pixels are not available.
+func readTexture(data *imgui.TextureData, textures map[unsafe.Pointer]*captureTexture) *captureTexture {
+	if data == nil || data.CData == nil {
+		return nil
+	}
+
+	key := unsafe.Pointer(data.CData)
+	if texture, ok := textures[key]; ok {
+		return texture
+	}
+
+	var texture *captureTexture
+
+	// pixels are owned by imgui
+	if pixels := data.Pixels(); pixels != 0 {
+		texture = &captureTexture{
+			width:  int(data.Width()),
+			height: int(data.Height()),
+			alpha8: data.Format() == imgui.TextureFormatAlpha8,
+		}
+
+		texture.pixels = unsafe.Slice(*(**byte)(unsafe.Pointer(&pixels)), int(data.Width()*data.Height()*data.BytesPerPixel()))
+	}
+
+	textures[key] = texture
+
+	return texture
+}
+
+// rasterizeTriangles draws triangles to img like imgui's renderers. origin is the screen position
+// of the top-left corner of img and scale is the count of pixels per screen unit.
+// img is cleared with background first.
+func rasterizeTriangles(img *image.NRGBA, origin, scale imgui.Vec2, background imgui.Vec4, triangles []captureTriangles) {
+	bounds := img.Bounds()
+	pixels := make([][3]float64, bounds.Dx()*bounds.Dy())
+
+	// renderers clear the frame with premultiplied color
+	for i := range pixels {
+		pixels[i] = [3]float64{
+			float64(background.X * background.W),
+			float64(background.Y * background.W),
+			float64(background.Z * background.W),
+		}
+	}
+
+	toPixels := func(x, y float32) (px, py float64) {
+		return float64((x - origin.X) * scale.X), float64((y - origin.Y) * scale.Y)
+	}
+
+	for _, t := range triangles {
+		clipMinX, clipMinY := toPixels(t.clip.X, t.clip.Y)
+		clipMaxX, clipMaxY := toPixels(t.clip.Z, t.clip.W)
+		clip := image.Rect(
+			int(math.Round(clipMinX)), int(math.Round(clipMinY)),
+			int(math.Round(clipMaxX)), int(math.Round(clipMaxY)),
+		).Intersect(bounds)
+
+		for i := 0; i+2 < len(t.vertices); i += 3 {
+			rasterizeTriangle(pixels, bounds.Dx(), clip, t.texture, t.vertices[i:i+3], toPixels)
+		}
+	}
+
+	for i, p := range pixels {
+		img.Pix[i*4] = uint8(math.Round(min(max(p[0], 0), 1) * math.MaxUint8))
+		img.Pix[i*4+1] = uint8(math.Round(min(max(p[1], 0), 1) * math.MaxUint8))
+		img.Pix[i*4+2] = uint8(math.Round(min(max(p[2], 0), 1) * math.MaxUint8))
+		img.Pix[i*4+3] = math.MaxUint8
+	}
+}
+
+// rasterizeTriangle blends triangle v into pixels (of width stride) within clip.
+// Pixels on an edge shared by two triangles are drawn only once.
+func rasterizeTriangle(pixels [][3]float64, stride int, clip image.Rectangle, texture *captureTexture, v []captureVertex, toPixels func(x, y float32) (float64, float64)) {
+	var x, y [3]float64
+	for i := range v {
+		x[i], y[i] = toPixels(v[i].pos.X, v[i].pos.Y)
+	}
+
+	// edge returns a value proportional to the distance of (px, py) from edge a-b
+	edge := func(a, b int, px, py float64) float64 {
+		return (x[b]-x[a])*(py-y[a]) - (y[b]-y[a])*(px-x[a])
+	}
+
+	area := edge(0, 1, x[2], y[2])
+	if area == 0 {
+		return
+	}
+
+	// vertices in the same order for all triangles, so that shared edges are opposite
+	order := [3]int{0, 1, 2}
+	if area < 0 {
+		order = [3]int{0, 2, 1}
+		area = -area
+	}
+
+	// ownsEdge tells which of the two triangles sharing an edge draws pixels lying on it
+	ownsEdge := func(a, b int) bool {
+		dx, dy := x[b]-x[a], y[b]-y[a]
+		return dy > 0 || (dy == 0 && dx < 0)
+	}
+
+	box := image.Rect(
+		int(math.Floor(min(x[0], x[1], x[2]))), int(math.Floor(min(y[0], y[1], y[2]))),
+		int(math.Ceil(max(x[0], x[1], x[2])))+1, int(math.Ceil(max(y[0], y[1], y[2])))+1,
+	).Intersect(clip)
+
+	colors := [3]color.NRGBA{}
+	for i := range v {
+		colors[i] = itemColor(v[i].col)
+	}
+
+	for py := box.Min.Y; py < box.Max.Y; py++ {
+		for px := box.Min.X; px < box.Max.X; px++ {
+			cx, cy := float64(px)+0.5, float64(py)+0.5
+
+			var weights [3]float64
+
+			inside := true
+
+			for i := range 3 {
+				a, b := order[(i+1)%3], order[(i+2)%3]
+
+				e := edge(a, b, cx, cy)
+				if e < 0 || (e == 0 && !ownsEdge(a, b)) {
+					inside = false
+					break
+				}
+
+				weights[order[i]] = e / area
+			}
+
+			if !inside {
+				continue
+			}
+
+			var src [4]float64
+
+			u, w := 0.0, 0.0
+
+			for i := range 3 {
+				src[0] += weights[i] * float64(colors[i].R) / math.MaxUint8
+				src[1] += weights[i] * float64(colors[i].G) / math.MaxUint8
+				src[2] += weights[i] * float64(colors[i].B) / math.MaxUint8
+				src[3] += weights[i] * float64(colors[i].A) / math.MaxUint8
+				u += weights[i] * float64(v[i].uv.X)
+				w += weights[i] * float64(v[i].uv.Y)
+			}
+
+			texel := texture.sample(u, w)
+			for i := range src {
+				src[i] *= texel[i]
+			}
+
+			dst := &pixels[py*stride+px]
+			for i := range dst {
+				dst[i] = src[i]*src[3] + dst[i]*(1-src[3])
+			}
+		}
+	}
+}
+
+// sample returns color of the texture at (u, v) with bilinear filtering (values are 0-1).
+func (t *captureTexture) sample(u, v float64) (result [4]float64) {
+	if t == nil || t.width == 0 || t.height == 0 {
+		return [4]float64{1, 1, 1, 1}
+	}
+
+	fx, fy := u*float64(t.width)-0.5, v*float64(t.height)-0.5
+	x0, y0 := math.Floor(fx), math.Floor(fy)
+	dx, dy := fx-x0, fy-y0
+
+	for j := range 2 {
+		for i := range 2 {
+			weight := (1 - math.Abs(float64(i)-dx)) * (1 - math.Abs(float64(j)-dy))
+			if weight == 0 {
+				continue
+			}
+
+			texel := t.texel(int(x0)+i, int(y0)+j)
+			for k := range result {
+				result[k] += weight * texel[k]
+			}
+		}
+	}
+
+	return result
+}
+
+// texel returns color of pixel (x, y) clamped to the texture.
+func (t *captureTexture) texel(x, y int) [4]float64 {
+	x, y = min(max(x, 0), t.width-1), min(max(y, 0), t.height-1)
+
+	if t.alpha8 {
+		return [4]float64{1, 1, 1, float64(t.pixels[y*t.width+x]) / math.MaxUint8}
+	}
+
+	p := t.pixels[(y*t.width+x)*4:]
+
+	return [4]float64{
+		float64(p[0]) / math.MaxUint8,
+		float64(p[1]) / math.MaxUint8,
+		float64(p[2]) / math.MaxUint8,
+		float64(p[3]) / math.MaxUint8,
+	}
+}
@@ -0,0 +1,98 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/stretchr/testify/assert"
+)
+
+// captureQuad returns a rectangle as two triangles like imgui's PrimRect.
+func captureQuad(x0, y0, x1, y1 float32, col uint32, texture *captureTexture) captureTriangles {
+	a := captureVertex{pos: imgui.Vec2{X: x0, Y: y0}, col: col}
+	b := captureVertex{pos: imgui.Vec2{X: x1, Y: y0}, uv: imgui.Vec2{X: 1}, col: col}
+	c := captureVertex{pos: imgui.Vec2{X: x1, Y: y1}, uv: imgui.Vec2{X: 1, Y: 1}, col: col}
+	d := captureVertex{pos: imgui.Vec2{X: x0, Y: y1}, uv: imgui.Vec2{Y: 1}, col: col}
+
+	return captureTriangles{
+		clip:     imgui.Vec4{X: -1000, Y: -1000, Z: 1000, W: 1000},
+		texture:  texture,
+		vertices: []captureVertex{a, b, c, a, c, d},
+	}
+}
+
+func Test_rasterizeTriangles(t *testing.T) {
+	const (
+		red          = 0xff0000ff
+		translucent  = 0x800000ff
+		transparent  = 0
+		imageWidth   = 8
+		imageHeight  = 6
+		imageOriginX = 100
+		imageOriginY = 50
+	)
+
+	black := imgui.Vec4{W: 1}
+	origin := imgui.Vec2{X: imageOriginX, Y: imageOriginY}
+	one := imgui.Vec2{X: 1, Y: 1}
+
+	t.Run("opaque quad", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+		rasterizeTriangles(img, origin, one, black, []captureTriangles{
+			captureQuad(imageOriginX, imageOriginY, imageOriginX+4, imageOriginY+imageHeight, red, nil),
+		})
+
+		assert.Equal(t, color.NRGBA{R: 255, A: 255}, img.NRGBAAt(0, 0))
+		assert.Equal(t, color.NRGBA{R: 255, A: 255}, img.NRGBAAt(3, 5))
+		assert.Equal(t, color.NRGBA{A: 255}, img.NRGBAAt(4, 0), "pixels outside of the quad should have background color")
+	})
+
+	t.Run("translucent quad is blended once", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+		rasterizeTriangles(img, origin, one, black, []captureTriangles{
+			captureQuad(imageOriginX, imageOriginY, imageOriginX+imageHeight, imageOriginY+imageHeight, translucent, nil),
+		})
+
+		for i := range imageHeight {
+			assert.Equal(t, color.NRGBA{R: 128, A: 255}, img.NRGBAAt(i, i), "pixels on the shared edge shouldn't be drawn twice")
+		}
+	})
+
+	t.Run("clip rectangle", func(t *testing.T) {
+		quad := captureQuad(imageOriginX, imageOriginY, imageOriginX+imageWidth, imageOriginY+imageHeight, red, nil)
+		quad.clip = imgui.Vec4{X: imageOriginX, Y: imageOriginY, Z: imageOriginX + 2, W: imageOriginY + imageHeight}
+
+		img := image.NewNRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+		rasterizeTriangles(img, origin, one, black, []captureTriangles{quad})
+
+		assert.Equal(t, color.NRGBA{R: 255, A: 255}, img.NRGBAAt(1, 3))
+		assert.Equal(t, color.NRGBA{A: 255}, img.NRGBAAt(2, 3), "pixels outside of the clip rectangle shouldn't be drawn")
+	})
+
+	t.Run("texture", func(t *testing.T) {
+		// left half of the texture is transparent
+		texture := &captureTexture{width: 2, height: 1, pixels: []byte{0, 255}, alpha8: true}
+
+		img := image.NewNRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+		rasterizeTriangles(img, origin, one, black, []captureTriangles{
+			captureQuad(imageOriginX, imageOriginY, imageOriginX+imageWidth, imageOriginY+imageHeight, red, texture),
+		})
+
+		assert.Equal(t, color.NRGBA{A: 255}, img.NRGBAAt(0, 0), "transparent texels shouldn't be drawn")
+		assert.Equal(t, color.NRGBA{R: 255, A: 255}, img.NRGBAAt(imageWidth-1, 0))
+	})
+
+	t.Run("framebuffer scale", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+		rasterizeTriangles(img, origin, imgui.Vec2{X: 2, Y: 2}, black, []captureTriangles{
+			captureQuad(imageOriginX, imageOriginY, imageOriginX+2, imageOriginY+1, red, nil),
+			captureQuad(imageOriginX, imageOriginY, imageOriginX+1, imageOriginY+1, transparent, nil),
+		})
+
+		assert.Equal(t, color.NRGBA{R: 255, A: 255}, img.NRGBAAt(3, 1))
+		assert.Equal(t, color.NRGBA{A: 255}, img.NRGBAAt(4, 1))
+		assert.Equal(t, color.NRGBA{A: 255}, img.NRGBAAt(3, 2))
+	})
+}
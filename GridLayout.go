@@ -0,0 +1,293 @@
+package giu
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+type gridTrackKind byte
+
+const (
+	gridTrackAuto gridTrackKind = iota
+	gridTrackFixed
+	gridTrackFraction
+)
+
+// GridTrack defines size of a column or a row of GridLayoutWidget.
+// Create it with GridFixed, GridFraction or GridAuto.
+type GridTrack struct {
+	kind gridTrackKind
+	size float32
+}
+
+// GridFixed creates a track of size pixels.
+func GridFixed(size float32) GridTrack {
+	return GridTrack{kind: gridTrackFixed, size: size}
+}
+
+// GridFraction creates a track taking fraction of the space left by fixed and auto tracks
+// (in ratio to fractions of other tracks, like fr unit in CSS).
+func GridFraction(fraction float32) GridTrack {
+	return GridTrack{kind: gridTrackFraction, size: fraction}
+}
+
+// GridAuto creates a track as big as its largest item.
+func GridAuto() GridTrack {
+	return GridTrack{kind: gridTrackAuto}
+}
+
+var _ Widget = &GridItemWidget{}
+
+// GridItemWidget places a widget in a cell of GridLayoutWidget.
+type GridItemWidget struct {
+	widget           Widget
+	row, col         int
+	rowSpan, colSpan int
+}
+
+// GridItem creates a new GridItemWidget placing widget at row and col (counted from 0).
+// Negative row and col are treated as 0.
+func GridItem(row, col int, widget Widget) *GridItemWidget {
+	return &GridItemWidget{
+		widget:  widget,
+		row:     max(row, 0),
+		col:     max(col, 0),
+		rowSpan: 1,
+		colSpan: 1,
+	}
+}
+
+// Span makes the item span rows and cols.
+func (g *GridItemWidget) Span(rows, cols int) *GridItemWidget {
+	g.rowSpan, g.colSpan = max(rows, 1), max(cols, 1)
+	return g
+}
+
+// Build implements Widget interface.
+func (g *GridItemWidget) Build() {
+	g.widget.Build()
+}
+
+var _ Widget = &GridLayoutWidget{}
+
+// GridLayoutWidget is a container placing items in cells of a grid like CSS grid.
+// Items are measured when they are built and their sizes are used to compute auto tracks
+// in the next frame. Items get width of their cells as item width (see PushItemWidth)
+// and ChildWidget items of zero size are resized to their cells. A size which an item only took
+// from its cell doesn't count to auto tracks, so ChildWidget of zero size has no size of content.
+type GridLayoutWidget struct {
+	id             ID
+	columns        []GridTrack
+	rows           []GridTrack
+	items          []*GridItemWidget
+	colGap, rowGap float32
+	width, height  float32
+}
+
+// GridLayout creates a new GridLayoutWidget (not to be confused with Grid, which is a GizmoI).
+func GridLayout() *GridLayoutWidget {
+	return &GridLayoutWidget{
+		id: GenAutoID("GridLayout"),
+	}
+}
+
+// ID sets the internal id of the grid.
+func (g *GridLayoutWidget) ID(id ID) *GridLayoutWidget {
+	g.id = id
+	return g
+}
+
+// Columns sets column tracks.
+func (g *GridLayoutWidget) Columns(tracks ...GridTrack) *GridLayoutWidget {
+	g.columns = tracks
+	return g
+}
+
+// Rows sets row tracks. Rows which are not defined (but used by items) are auto.
+func (g *GridLayoutWidget) Rows(tracks ...GridTrack) *GridLayoutWidget {
+	g.rows = tracks
+	return g
+}
+
+// Items sets items of the grid.
+func (g *GridLayoutWidget) Items(items ...*GridItemWidget) *GridLayoutWidget {
+	g.items = items
+	return g
+}
+
+// Gap sets space between columns and rows.
+func (g *GridLayoutWidget) Gap(col, row float32) *GridLayoutWidget {
+	g.colGap, g.rowGap = col, row
+	return g
+}
+
+// Size sets size of the grid. Zero width means the available width, zero height means
+// the height of content (fraction rows then behave like auto ones).
+func (g *GridLayoutWidget) Size(width, height float32) *GridLayoutWidget {
+	g.width, g.height = width, height
+	return g
+}
+
+type gridState struct {
+	// sizes are natural sizes of items measured in the last frames.
+	sizes []imgui.Vec2
+	// relayouts counts consecutive frames in which the sizes changed (see flexMaxRelayouts).
+	relayouts int
+}
+
+// Dispose implements Disposable interface.
+func (s *gridState) Dispose() {
+	// noop
+}
+
+func (g *GridLayoutWidget) getState() *gridState {
+	state := GetState[gridState](Context, g.id)
+	if state == nil {
+		state = &gridState{}
+		SetState(Context, g.id, state)
+	}
+
+	if len(state.sizes) != len(g.items) {
+		state.sizes = make([]imgui.Vec2, len(g.items))
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (g *GridLayoutWidget) Build() {
+	if len(g.items) == 0 {
+		return
+	}
+
+	state := g.getState()
+
+	width := g.width
+	if width == 0 {
+		width = imgui.ContentRegionAvail().X
+	}
+
+	colCount, rowCount := len(g.columns), len(g.rows)
+	for _, item := range g.items {
+		colCount = max(colCount, item.col+item.colSpan)
+		rowCount = max(rowCount, item.row+item.rowSpan)
+	}
+
+	// auto tracks are as big as their largest item (spanning a single track)
+	colContent, rowContent := make([]float32, colCount), make([]float32, rowCount)
+
+	for i, item := range g.items {
+		if item.colSpan == 1 {
+			colContent[item.col] = max(colContent[item.col], state.sizes[i].X)
+		}
+
+		if item.rowSpan == 1 {
+			rowContent[item.row] = max(rowContent[item.row], state.sizes[i].Y)
+		}
+	}
+
+	cols := gridTracks(g.columns, colContent, width, g.colGap)
+	rows := gridTracks(g.rows, rowContent, g.height, g.rowGap)
+
+	start := imgui.CursorPos()
+	changed := false
+
+	imgui.BeginGroup()
+
+	for i, item := range g.items {
+		pos := imgui.Vec2{X: start.X + cols[item.col], Y: start.Y + rows[item.row]}
+		size := imgui.Vec2{
+			X: cols[item.col+item.colSpan] - cols[item.col] - g.colGap,
+			Y: rows[item.row+item.rowSpan] - rows[item.row] - g.rowGap,
+		}
+
+		natural := gridNaturalSize(item.widget, buildBox(item.widget, i, pos, size), size, state.sizes[i])
+		changed = changed || sizeChanged(natural, state.sizes[i])
+		state.sizes[i] = natural
+	}
+
+	// make the group as big as the grid
+	imgui.SetCursorPos(start)
+	imgui.Dummy(imgui.Vec2{X: cols[colCount] - g.colGap, Y: rows[rowCount] - g.rowGap})
+	imgui.EndGroup()
+
+	// sizes depending on the layout could change forever, so the relayout is limited
+	switch {
+	case !changed:
+		state.relayouts = 0
+	case state.relayouts < flexMaxRelayouts:
+		state.relayouts++
+
+		Update()
+	}
+}
+
+// gridNaturalSize returns the natural size of item w from its size measured in a cell of size cell.
+// last is the natural size from previous frames. See flexNaturalSize.
+// Size of ChildWidget is its natural size, unless it is zero (then the child takes its size
+// from the layout only and its natural size is 0).
+func gridNaturalSize(w Widget, measured, cell, last imgui.Vec2) imgui.Vec2 {
+	natural := imgui.Vec2{
+		X: flexNaturalSize(measured.X, cell.X, last.X, last.X),
+		Y: flexNaturalSize(measured.Y, cell.Y, last.Y, last.Y),
+	}
+
+	if child, ok := w.(*ChildWidget); ok {
+		natural = measured
+
+		if child.width == 0 {
+			natural.X = 0
+		}
+
+		if child.height == 0 {
+			natural.Y = 0
+		}
+	}
+
+	return natural
+}
+
+// gridTracks computes positions of tracks. content contains sizes of content of the tracks
+// (its length is the count of tracks; tracks missing in tracks are auto).
+// available is the size of the grid (0 means the size of content).
+// It returns positions of tracks and the end of the grid (plus gap) as the last element.
+func gridTracks(tracks []GridTrack, content []float32, available, gap float32) []float32 {
+	sizes := make([]float32, len(content))
+	free := available - gap*float32(len(content)-1)
+	fractions := float32(0)
+
+	for i := range sizes {
+		track := GridAuto()
+		if i < len(tracks) {
+			track = tracks[i]
+		}
+
+		switch {
+		case track.kind == gridTrackFixed:
+			sizes[i] = track.size
+		case track.kind == gridTrackFraction && available > 0:
+			fractions += track.size
+			continue
+		default:
+			sizes[i] = content[i]
+		}
+
+		free -= sizes[i]
+	}
+
+	if fractions > 0 {
+		free = max(free, 0)
+
+		for i := range sizes {
+			if i < len(tracks) && tracks[i].kind == gridTrackFraction {
+				sizes[i] = free * tracks[i].size / fractions
+			}
+		}
+	}
+
+	positions := make([]float32, len(sizes)+1)
+	for i, size := range sizes {
+		positions[i+1] = positions[i] + size + gap
+	}
+
+	return positions
+}
@@ -0,0 +1,72 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_gridTracks(t *testing.T) {
+	tests := []struct {
+		name      string
+		tracks    []GridTrack
+		content   []float32
+		available float32
+		gap       float32
+		expected  []float32
+	}{
+		{
+			name:      "fixed, auto and fractions",
+			tracks:    []GridTrack{GridFixed(100), GridAuto(), GridFraction(1), GridFraction(2)},
+			content:   []float32{0, 50, 30, 30},
+			available: 540,
+			gap:       10,
+			expected:  []float32{0, 110, 170, 300, 550},
+		},
+		{
+			name:     "implicit auto tracks",
+			tracks:   []GridTrack{GridFixed(20)},
+			content:  []float32{5, 40},
+			expected: []float32{0, 20, 60},
+		},
+		{
+			name:      "fractions of content size",
+			tracks:    []GridTrack{GridFraction(1), GridFraction(1)},
+			content:   []float32{15, 25},
+			available: 0,
+			gap:       5,
+			expected:  []float32{0, 20, 50},
+		},
+		{
+			name:      "no space left",
+			tracks:    []GridTrack{GridFixed(100), GridFraction(1)},
+			content:   []float32{0, 0},
+			available: 50,
+			expected:  []float32{0, 100, 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDeltaSlice(t, tt.expected, gridTracks(tt.tracks, tt.content, tt.available, tt.gap), 1e-4)
+		})
+	}
+}
+
+func TestGridItem_negativeCell(t *testing.T) {
+	item := GridItem(-1, -2, &LabelWidget{label: "x"})
+	assert.Zero(t, item.row)
+	assert.Zero(t, item.col)
+}
+
+func Test_gridNaturalSize(t *testing.T) {
+	label := &LabelWidget{label: "x"}
+	assert.Equal(t, imgui.Vec2{X: 30, Y: 20}, gridNaturalSize(label, imgui.Vec2{X: 30, Y: 20}, imgui.Vec2{X: 100, Y: 20}, imgui.Vec2{X: 40, Y: 20}))
+	assert.Equal(t, imgui.Vec2{X: 40, Y: 20}, gridNaturalSize(label, imgui.Vec2{X: 100, Y: 20}, imgui.Vec2{X: 100, Y: 20}, imgui.Vec2{X: 40, Y: 20}),
+		"cell width taken by the item shouldn't count to auto tracks")
+
+	child := &ChildWidget{height: 50}
+	assert.Equal(t, imgui.Vec2{Y: 50}, gridNaturalSize(child, imgui.Vec2{X: 500, Y: 50}, imgui.Vec2{Y: 50}, imgui.Vec2{}),
+		"child without width shouldn't make its auto column wide")
+}
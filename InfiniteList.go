@@ -0,0 +1,323 @@
+package giu
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"golang.org/x/image/colornames"
+)
+
+// PageLoader loads a page of items of InfiniteListWidget. cursor is empty for the first page,
+// next is the cursor of the following page (empty if there are no more pages).
+type PageLoader[T any] func(cursor string) (items []T, next string, err error)
+
+type infiniteListState[T any] struct {
+	m *sync.Mutex
+
+	items []T
+	// cursor is the cursor of the next page to load.
+	cursor  string
+	done    bool
+	loading bool
+	err     error
+	// generation is increased by Reload so that pages requested before are dropped.
+	generation int
+
+	// prepended is the count of items prepended since the last frame.
+	prepended  int
+	itemHeight float32
+	atBottom   bool
+	// scrollY and statusHeight are scroll position of the list and height of the status row
+	// (in reverse mode) in the last frame.
+	scrollY      float32
+	statusHeight float32
+}
+
+// Dispose implements Disposable interface.
+func (s *infiniteListState[T]) Dispose() {
+	// noop
+}
+
+var _ Widget = &InfiniteListWidget[any]{}
+
+// InfiniteListWidget is a scrollable list loading pages of items when the user scrolls near its end.
+// Pages are loaded in a goroutine and the loaded items are kept in the widget's state.
+// Items are expected to be of the same height.
+type InfiniteListWidget[T any] struct {
+	id            ID
+	load          PageLoader[T]
+	item          func(item T) Widget
+	size          imgui.Vec2
+	threshold     int
+	reverse       bool
+	stickToBottom bool
+}
+
+// InfiniteList creates a new InfiniteListWidget loading pages with load; item returns widget of an item.
+func InfiniteList[T any](load PageLoader[T], item func(item T) Widget) *InfiniteListWidget[T] {
+	return &InfiniteListWidget[T]{
+		id:        GenAutoID("InfiniteList"),
+		load:      load,
+		item:      item,
+		threshold: 10,
+	}
+}
+
+// ID sets the internal id of the list.
+func (l *InfiniteListWidget[T]) ID(id ID) *InfiniteListWidget[T] {
+	l.id = id
+	return l
+}
+
+// Size sets size of the list (see Child).
+func (l *InfiniteListWidget[T]) Size(width, height float32) *InfiniteListWidget[T] {
+	l.size = imgui.Vec2{X: width, Y: height}
+	return l
+}
+
+// Threshold sets how many items before the end of the list the next page is requested (10 by default).
+func (l *InfiniteListWidget[T]) Threshold(items int) *InfiniteListWidget[T] {
+	l.threshold = items
+	return l
+}
+
+// Reverse makes the list load pages when scrolled near the top. Loaded pages are prepended
+// (e.g. chat history) and the scroll position is kept. The list starts at the bottom
+// and stays there until the user scrolls up.
+func (l *InfiniteListWidget[T]) Reverse(b bool) *InfiniteListWidget[T] {
+	l.reverse = b
+	return l
+}
+
+// StickToBottom keeps the list scrolled to the bottom when items are added (e.g. log or chat),
+// unless the user scrolled up.
+func (l *InfiniteListWidget[T]) StickToBottom(b bool) *InfiniteListWidget[T] {
+	l.stickToBottom = b
+	return l
+}
+
+// Append adds items to the bottom of the list (e.g. new messages of a chat).
+// The items are stored in the list's state, so call it only once for each item.
+func (l *InfiniteListWidget[T]) Append(items ...T) *InfiniteListWidget[T] {
+	state := l.getState()
+
+	state.m.Lock()
+	state.items = append(state.items, items...)
+	state.m.Unlock()
+
+	return l
+}
+
+// Reload drops loaded items and starts loading from the first page.
+func (l *InfiniteListWidget[T]) Reload() *InfiniteListWidget[T] {
+	state := l.getState()
+
+	state.m.Lock()
+	state.reset()
+	state.m.Unlock()
+
+	return l
+}
+
+func (l *InfiniteListWidget[T]) getState() *infiniteListState[T] {
+	state := GetState[infiniteListState[T]](Context, l.id)
+	if state == nil {
+		state = &infiniteListState[T]{m: &sync.Mutex{}}
+		state.reset()
+		SetState(Context, l.id, state)
+	}
+
+	return state
+}
+
+// reset drops loaded items. state must be locked.
+func (s *infiniteListState[T]) reset() {
+	s.items = nil
+	s.cursor = ""
+	s.done = false
+	s.loading = false
+	s.err = nil
+	s.generation++
+	s.prepended = 0
+	s.atBottom = true
+}
+
+// Build implements Widget interface.
+func (l *InfiniteListWidget[T]) Build() {
+	state := l.getState()
+
+	state.m.Lock()
+
+	// keep the prepended items above the visible area
+	if state.prepended > 0 {
+		imgui.SetNextWindowScroll(imgui.Vec2{X: -1, Y: prependScroll(state)})
+
+		state.prepended = 0
+	}
+
+	// the list is built unlocked, so that its items can call Append or Reload.
+	// Items of the slice are never modified (only new slices are created or items appended).
+	frame := infiniteListFrame[T]{
+		items:    state.items,
+		loading:  state.loading,
+		err:      state.err,
+		atBottom: state.atBottom,
+	}
+
+	state.m.Unlock()
+
+	if imgui.BeginChildStrV(l.id.String(), l.size, 0, 0) {
+		l.buildItems(state, frame)
+	}
+
+	imgui.EndChild()
+}
+
+// infiniteListFrame is a snapshot of infiniteListState used to build a frame.
+type infiniteListFrame[T any] struct {
+	items    []T
+	loading  bool
+	err      error
+	atBottom bool
+}
+
+// buildItems builds the visible items and the loading (or error) row of frame and then updates state.
+func (l *InfiniteListWidget[T]) buildItems(state *infiniteListState[T], frame infiniteListFrame[T]) {
+	var retry bool
+
+	statusHeight := float32(0)
+
+	if l.reverse {
+		y := imgui.CursorPosY()
+		retry = l.buildStatusRow(frame)
+		statusHeight = imgui.CursorPosY() - y
+	}
+
+	clipper := imgui.NewListClipper()
+	defer clipper.Destroy()
+
+	clipper.Begin(int32(len(frame.items)))
+
+	nearEnd := len(frame.items) == 0
+
+	for clipper.Step() {
+		start, end := int(clipper.DisplayStart()), int(clipper.DisplayEnd())
+
+		for i := start; i < end; i++ {
+			l.item(frame.items[i]).Build()
+		}
+
+		if end > start {
+			if l.reverse {
+				nearEnd = nearEnd || start <= l.threshold
+			} else {
+				nearEnd = nearEnd || end >= len(frame.items)-l.threshold
+			}
+		}
+	}
+
+	itemHeight := clipper.ItemsHeight()
+
+	clipper.End()
+
+	if !l.reverse {
+		retry = l.buildStatusRow(frame)
+	}
+
+	if (l.stickToBottom || l.reverse) && frame.atBottom {
+		imgui.SetScrollHereYV(1)
+	}
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	if itemHeight > 0 {
+		state.itemHeight = itemHeight
+	}
+
+	state.statusHeight = statusHeight
+	state.atBottom = imgui.ScrollY() >= imgui.ScrollMaxY()-1
+	state.scrollY = imgui.ScrollY()
+
+	if retry {
+		state.err = nil
+	}
+
+	if nearEnd && !state.done && !state.loading && state.err == nil {
+		l.loadPage(state)
+	}
+}
+
+// prependScroll returns scroll position keeping the same items visible after items were prepended.
+// The status row above the items disappears when the page is loaded (unless loading failed).
+// state must be locked.
+func prependScroll[T any](state *infiniteListState[T]) float32 {
+	scroll := state.scrollY + float32(state.prepended)*state.itemHeight
+	if !state.loading && state.err == nil {
+		scroll -= state.statusHeight
+	}
+
+	return max(scroll, 0)
+}
+
+// buildStatusRow builds the loading row or the error with a retry button.
+// It returns true if the retry button was clicked.
+func (l *InfiniteListWidget[T]) buildStatusRow(frame infiniteListFrame[T]) (retry bool) {
+	switch {
+	case frame.loading:
+		buildLoadingRow()
+	case frame.err != nil:
+		imgui.PushStyleColorVec4(imgui.ColText, ToVec4Color(colornames.Red))
+		imgui.TextUnformatted(frame.err.Error())
+		imgui.PopStyleColor()
+		imgui.SameLine()
+
+		return imgui.Button(Context.PrepareString("Retry"))
+	}
+
+	return false
+}
+
+// loadPage loads the next page in a goroutine. state must be locked.
+func (l *InfiniteListWidget[T]) loadPage(state *infiniteListState[T]) {
+	state.loading = true
+	cursor, generation := state.cursor, state.generation
+
+	go func() {
+		done := make(chan struct{})
+		go refreshUntil(done)
+
+		items, next, err := l.load(cursor)
+
+		close(done)
+
+		state.m.Lock()
+		if state.generation == generation {
+			state.addPage(items, next, err, l.reverse)
+		}
+		state.m.Unlock()
+
+		Update()
+	}()
+}
+
+// addPage stores result of loading a page. state must be locked.
+func (s *infiniteListState[T]) addPage(items []T, next string, err error, reverse bool) {
+	s.loading = false
+
+	if err != nil {
+		s.err = err
+		return
+	}
+
+	if reverse {
+		s.items = slices.Concat(items, s.items)
+		s.prepended += len(items)
+	} else {
+		s.items = append(s.items, items...)
+	}
+
+	s.cursor = next
+	s.done = next == ""
+}
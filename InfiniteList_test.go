@@ -0,0 +1,51 @@
+package giu
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errOffline = errors.New("offline")
+
+func Test_infiniteListState_addPage(t *testing.T) {
+	state := &infiniteListState[int]{m: &sync.Mutex{}}
+	state.reset()
+
+	state.loading = true
+	state.addPage([]int{3, 4}, "2", nil, true)
+	state.addPage([]int{1, 2}, "", nil, true)
+
+	assert.Equal(t, []int{1, 2, 3, 4}, state.items, "pages should be prepended in reverse mode")
+	assert.Equal(t, 4, state.prepended)
+	assert.True(t, state.done)
+	assert.False(t, state.loading)
+
+	state.reset()
+
+	state.addPage(nil, "", errOffline, false)
+	assert.ErrorIs(t, state.err, errOffline)
+	assert.False(t, state.done, "failed page should be retried")
+
+	state.err = nil
+	state.addPage([]int{1}, "next", nil, false)
+	state.addPage([]int{2}, "", nil, false)
+	assert.Equal(t, []int{1, 2}, state.items)
+	assert.Empty(t, state.cursor)
+}
+
+func Test_prependScroll(t *testing.T) {
+	state := &infiniteListState[int]{m: &sync.Mutex{}}
+	state.scrollY, state.itemHeight, state.statusHeight, state.prepended = 10, 20, 30, 2
+
+	assert.InDelta(t, 20, prependScroll(state), 0.001, "status row should be removed when the page is loaded")
+
+	state.err = errOffline
+	assert.InDelta(t, 50, prependScroll(state), 0.001, "status row should stay when loading failed")
+
+	state.err = nil
+	state.scrollY, state.prepended = 0, 1
+	assert.InDelta(t, 0, prependScroll(state), 0.001, "scroll should not be negative")
+}
@@ -0,0 +1,426 @@
+package giu
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TreeDataProvider provides nodes of LazyTreeTableWidget.
+type TreeDataProvider[N comparable] interface {
+	// Children returns children of node. It is called when the node is expanded for the first time
+	// (and after LazyTreeTableWidget.Reload).
+	Children(node N) []N
+	// HasChildren tells if node can be expanded. It should be cheap (e.g. shouldn't list the children).
+	HasChildren(node N) bool
+}
+
+// lazyTreeRow is a visible row of LazyTreeTableWidget.
+type lazyTreeRow[N comparable] struct {
+	node        N
+	depth       int
+	hasChildren bool
+	// loading is true for the row displayed in place of children being loaded.
+	loading bool
+}
+
+type lazyTreeTableState[N comparable] struct {
+	m *sync.Mutex
+
+	open        map[N]bool
+	children    map[N][]N
+	hasChildren map[N]bool
+	loading     map[N]bool
+	// reloadQueued contains nodes which should be reloaded when they are loaded.
+	reloadQueued map[N]bool
+
+	// rows are visible rows computed for roots.
+	rows  []lazyTreeRow[N]
+	roots []N
+	dirty bool
+}
+
+// Dispose implements Disposable interface.
+func (s *lazyTreeTableState[N]) Dispose() {
+	// noop
+}
+
+var _ Widget = &LazyTreeTableWidget[string]{}
+
+// LazyTreeTableWidget is a tree table which fetches children of nodes from a TreeDataProvider
+// only when they are expanded. Only visible rows are built, so it can display huge trees
+// (e.g. a filesystem).
+// Nodes are keys of the expansion state, so they should be unique (e.g. paths).
+type LazyTreeTableWidget[N comparable] struct {
+	id           ID
+	provider     TreeDataProvider[N]
+	roots        []N
+	row          func(node N) (label string, cells Layout)
+	flags        TableFlags
+	nodeFlags    TreeNodeFlags
+	size         imgui.Vec2
+	columns      []*TableColumnWidget
+	freezeRow    int
+	freezeColumn int
+	async        bool
+	reload       []N
+}
+
+// LazyTreeTable creates a new LazyTreeTableWidget displaying roots and their descendants provided by provider.
+func LazyTreeTable[N comparable](provider TreeDataProvider[N], roots ...N) *LazyTreeTableWidget[N] {
+	return &LazyTreeTableWidget[N]{
+		id:           GenAutoID("LazyTreeTable"),
+		provider:     provider,
+		roots:        roots,
+		flags:        TableFlagsBordersV | TableFlagsBordersOuterH | TableFlagsResizable | TableFlagsRowBg | TableFlagsNoBordersInBody | TableFlagsScrollY,
+		freezeRow:    -1,
+		freezeColumn: -1,
+	}
+}
+
+// ID sets the internal id of the table.
+func (t *LazyTreeTableWidget[N]) ID(id ID) *LazyTreeTableWidget[N] {
+	t.id = id
+	return t
+}
+
+// Row sets function returning label of node's tree node (displayed in the first column)
+// and widgets of the other columns. By default, the label is fmt.Sprint(node).
+func (t *LazyTreeTableWidget[N]) Row(row func(node N) (label string, cells Layout)) *LazyTreeTableWidget[N] {
+	t.row = row
+	return t
+}
+
+// Flags sets table flags.
+func (t *LazyTreeTableWidget[N]) Flags(flags TableFlags) *LazyTreeTableWidget[N] {
+	t.flags = flags
+	return t
+}
+
+// NodeFlags sets flags of tree nodes.
+func (t *LazyTreeTableWidget[N]) NodeFlags(flags TreeNodeFlags) *LazyTreeTableWidget[N] {
+	t.nodeFlags = flags
+	return t
+}
+
+// Size sets size of the table.
+func (t *LazyTreeTableWidget[N]) Size(width, height float32) *LazyTreeTableWidget[N] {
+	t.size = imgui.Vec2{X: width, Y: height}
+	return t
+}
+
+// Columns sets table's columns.
+func (t *LazyTreeTableWidget[N]) Columns(cols ...*TableColumnWidget) *LazyTreeTableWidget[N] {
+	t.columns = cols
+	return t
+}
+
+// Freeze columns/rows so they stay visible when scrolled.
+func (t *LazyTreeTableWidget[N]) Freeze(col, row int) *LazyTreeTableWidget[N] {
+	t.freezeColumn = col
+	t.freezeRow = row
+
+	return t
+}
+
+// Async makes the table call provider's Children in a goroutine.
+// A spinner row is displayed until the children are loaded.
+func (t *LazyTreeTableWidget[N]) Async(b bool) *LazyTreeTableWidget[N] {
+	t.async = b
+	return t
+}
+
+// Reload makes the table fetch children of nodes again (e.g. when they changed).
+func (t *LazyTreeTableWidget[N]) Reload(nodes ...N) *LazyTreeTableWidget[N] {
+	t.reload = append(t.reload, nodes...)
+	return t
+}
+
+func (t *LazyTreeTableWidget[N]) getState() *lazyTreeTableState[N] {
+	state := GetState[lazyTreeTableState[N]](Context, t.id)
+	if state == nil {
+		state = newLazyTreeTableState[N]()
+		SetState(Context, t.id, state)
+	}
+
+	return state
+}
+
+func newLazyTreeTableState[N comparable]() *lazyTreeTableState[N] {
+	return &lazyTreeTableState[N]{
+		m:            &sync.Mutex{},
+		open:         make(map[N]bool),
+		children:     make(map[N][]N),
+		hasChildren:  make(map[N]bool),
+		loading:      make(map[N]bool),
+		reloadQueued: make(map[N]bool),
+		dirty:        true,
+	}
+}
+
+// Build implements Widget interface.
+func (t *LazyTreeTableWidget[N]) Build() {
+	state := t.getState()
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	for _, node := range t.reload {
+		t.reloadNode(state, node)
+	}
+
+	t.reload = nil
+
+	if state.dirty || !slices.Equal(state.roots, t.roots) {
+		state.rows = flattenLazyTree(t.provider, t.roots, state)
+		state.roots = slices.Clone(t.roots)
+		state.dirty = false
+	}
+
+	colCount := max(len(t.columns), 1)
+
+	if !imgui.BeginTableV(t.id.String(), int32(colCount), imgui.TableFlags(t.flags), t.size, 0) {
+		return
+	}
+
+	if t.freezeColumn >= 0 && t.freezeRow >= 0 {
+		imgui.TableSetupScrollFreeze(int32(t.freezeColumn), int32(t.freezeRow))
+	}
+
+	if len(t.columns) > 0 {
+		for _, col := range t.columns {
+			col.BuildTableColumn()
+		}
+
+		imgui.TableHeadersRow()
+	}
+
+	indentSpacing := imgui.CurrentStyle().IndentSpacing()
+
+	clipper := imgui.NewListClipper()
+	defer clipper.Destroy()
+
+	clipper.Begin(int32(len(state.rows)))
+
+	for clipper.Step() {
+		for i := clipper.DisplayStart(); i < clipper.DisplayEnd(); i++ {
+			row := state.rows[i]
+
+			// the indent must be set before the row begins to move the first column
+			indent := float32(row.depth) * indentSpacing
+			if indent > 0 {
+				imgui.IndentV(indent)
+			}
+
+			imgui.TableNextRow()
+			imgui.TableNextColumn()
+
+			if row.loading {
+				buildLoadingRow()
+			} else {
+				t.buildNode(state, row)
+			}
+
+			if indent > 0 {
+				imgui.UnindentV(indent)
+			}
+		}
+	}
+
+	clipper.End()
+
+	imgui.EndTable()
+}
+
+// buildNode builds tree node and cells of row. state must be locked.
+func (t *LazyTreeTableWidget[N]) buildNode(state *lazyTreeTableState[N], row lazyTreeRow[N]) {
+	var (
+		label string
+		cells Layout
+	)
+
+	if t.row != nil {
+		label, cells = t.row(row.node)
+	} else {
+		label = fmt.Sprint(row.node)
+	}
+
+	// ID of the row must not change when rows above are expanded or collapsed
+	imgui.PushIDStr(fmt.Sprint(row.node))
+	defer imgui.PopID()
+
+	// parents of visible rows may be clipped, so tree nodes are indented manually instead of pushed
+	flags := t.nodeFlags | TreeNodeFlagsNoTreePushOnOpen
+	if !row.hasChildren {
+		flags |= TreeNodeFlagsLeaf
+	}
+
+	open := state.open[row.node]
+
+	imgui.SetNextItemOpen(open)
+
+	if imgui.TreeNodeExStrV(Context.PrepareString(label), imgui.TreeNodeFlags(flags)) != open && row.hasChildren {
+		t.toggle(state, row.node)
+	}
+
+	for _, w := range cells {
+		if isTableCell(w) {
+			imgui.TableNextColumn()
+		}
+
+		w.Build()
+	}
+}
+
+// toggle expands or collapses node, loading its children if needed. state must be locked.
+func (t *LazyTreeTableWidget[N]) toggle(state *lazyTreeTableState[N], node N) {
+	state.open[node] = !state.open[node]
+	state.dirty = true
+
+	// children may be still loading when the node is expanded again
+	if _, ok := state.children[node]; state.open[node] && !ok && !state.loading[node] {
+		t.load(state, node)
+	}
+}
+
+// reloadNode drops cached children of node and loads them again if the node is open.
+// If the children are being loaded, the node is reloaded after they are loaded. state must be locked.
+func (t *LazyTreeTableWidget[N]) reloadNode(state *lazyTreeTableState[N], node N) {
+	if state.loading[node] {
+		state.reloadQueued[node] = true
+		return
+	}
+
+	for _, child := range state.children[node] {
+		delete(state.hasChildren, child)
+	}
+
+	delete(state.children, node)
+	delete(state.hasChildren, node)
+
+	state.dirty = true
+
+	if state.open[node] {
+		t.load(state, node)
+	}
+}
+
+// load fetches children of node (in a goroutine if the table is async). state must be locked.
+func (t *LazyTreeTableWidget[N]) load(state *lazyTreeTableState[N], node N) {
+	if !t.async {
+		state.children[node] = t.provider.Children(node)
+		return
+	}
+
+	state.loading[node] = true
+
+	go func() {
+		done := make(chan struct{})
+		go refreshUntil(done)
+
+		children := t.provider.Children(node)
+
+		close(done)
+
+		state.m.Lock()
+		if state.loaded(node, children) {
+			t.reloadNode(state, node)
+		}
+		state.m.Unlock()
+
+		Update()
+	}()
+}
+
+// loaded stores children of node loaded in a goroutine. It returns true if the node should be reloaded
+// as Reload was called while the children were loading. state must be locked.
+func (s *lazyTreeTableState[N]) loaded(node N, children []N) (reload bool) {
+	s.children[node] = children
+	delete(s.loading, node)
+	s.dirty = true
+
+	reload = s.reloadQueued[node]
+	delete(s.reloadQueued, node)
+
+	return reload
+}
+
+// refreshUntil refreshes UI (to animate spinners) until done is closed.
+func refreshUntil(done <-chan struct{}) {
+	ticker := time.NewTicker(time.Second / 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			Update()
+		}
+	}
+}
+
+// buildLoadingRow builds a spinner followed by "Loading...".
+func buildLoadingRow() {
+	const dots = 8
+
+	size := imgui.TextLineHeight()
+	pos := imgui.CursorScreenPos()
+	center := imgui.Vec2{X: pos.X + size/2, Y: pos.Y + size/2}
+	color := *imgui.StyleColorVec4(imgui.ColText)
+	active := int(imgui.Time()*dots) % dots
+	drawList := imgui.WindowDrawList()
+
+	for i := range dots {
+		angle := 2 * math.Pi * float64(i) / dots
+		color.W = 1 - float32((active-i+dots)%dots)/dots
+
+		drawList.AddCircleFilled(imgui.Vec2{
+			X: center.X + size/3*float32(math.Sin(angle)),
+			Y: center.Y - size/3*float32(math.Cos(angle)),
+		}, size/12, imgui.ColorU32Vec4(color))
+	}
+
+	imgui.Dummy(imgui.Vec2{X: size, Y: size})
+	imgui.SameLine()
+	imgui.TextUnformatted(Context.PrepareString("Loading..."))
+}
+
+// flattenLazyTree returns visible rows of the tree: roots and children of open nodes.
+// Children of open nodes which are not loaded yet are replaced by a loading row.
+// HasChildren results are cached in state.
+func flattenLazyTree[N comparable](provider TreeDataProvider[N], roots []N, state *lazyTreeTableState[N]) []lazyTreeRow[N] {
+	var rows []lazyTreeRow[N]
+
+	var add func(nodes []N, depth int)
+	add = func(nodes []N, depth int) {
+		for _, node := range nodes {
+			hasChildren, ok := state.hasChildren[node]
+			if !ok {
+				hasChildren = provider.HasChildren(node)
+				state.hasChildren[node] = hasChildren
+			}
+
+			rows = append(rows, lazyTreeRow[N]{node: node, depth: depth, hasChildren: hasChildren})
+
+			if !hasChildren || !state.open[node] {
+				continue
+			}
+
+			if state.loading[node] {
+				rows = append(rows, lazyTreeRow[N]{node: node, depth: depth + 1, loading: true})
+				continue
+			}
+
+			add(state.children[node], depth+1)
+		}
+	}
+
+	add(roots, 0)
+
+	return rows
+}
@@ -0,0 +1,92 @@
+package giu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pathTree is a TreeDataProvider of slash-separated paths.
+type pathTree struct {
+	paths    []string
+	children int
+}
+
+func (p *pathTree) Children(node string) []string {
+	p.children++
+
+	var result []string
+
+	for _, path := range p.paths {
+		if name, ok := strings.CutPrefix(path, node+"/"); ok && !strings.Contains(name, "/") {
+			result = append(result, path)
+		}
+	}
+
+	return result
+}
+
+func (p *pathTree) HasChildren(node string) bool {
+	return len(p.Children(node)) > 0
+}
+
+func Test_flattenLazyTree(t *testing.T) {
+	provider := &pathTree{paths: []string{"a", "a/b", "a/b/c", "a/d", "e"}}
+	state := newLazyTreeTableState[string]()
+	table := &LazyTreeTableWidget[string]{provider: provider}
+
+	rows := flattenLazyTree(provider, []string{"a", "e"}, state)
+	assert.Equal(t, []lazyTreeRow[string]{
+		{node: "a", hasChildren: true},
+		{node: "e"},
+	}, rows)
+
+	table.toggle(state, "a")
+	assert.Equal(t, []string{"a/b", "a/d"}, state.children["a"], "children should be loaded when node is expanded")
+
+	rows = flattenLazyTree(provider, []string{"a", "e"}, state)
+	assert.Equal(t, []lazyTreeRow[string]{
+		{node: "a", hasChildren: true},
+		{node: "a/b", depth: 1, hasChildren: true},
+		{node: "a/d", depth: 1},
+		{node: "e"},
+	}, rows)
+
+	loads := provider.children
+
+	table.toggle(state, "a")
+	table.toggle(state, "a")
+	assert.Equal(t, loads, provider.children, "children should be fetched only once")
+
+	state.open["a/b"] = true
+	state.loading["a/b"] = true
+
+	rows = flattenLazyTree(provider, []string{"a"}, state)
+	assert.Equal(t, lazyTreeRow[string]{node: "a/b", depth: 2, loading: true}, rows[2], "loading row should be displayed")
+	loads = provider.children
+
+	table.toggle(state, "a/b")
+	table.toggle(state, "a/b")
+	assert.Equal(t, loads, provider.children, "loading children shouldn't be loaded again when node is expanded")
+}
+
+func TestLazyTreeTableWidget_reloadWhileLoading(t *testing.T) {
+	provider := &pathTree{paths: []string{"a", "a/b"}}
+	state := newLazyTreeTableState[string]()
+	table := &LazyTreeTableWidget[string]{provider: provider}
+
+	state.open["a"] = true
+	state.loading["a"] = true
+
+	table.reloadNode(state, "a")
+	assert.True(t, state.reloadQueued["a"], "reload of a loading node should be queued")
+
+	assert.True(t, state.loaded("a", nil), "queued reload should be reported when children are loaded")
+	assert.False(t, state.loading["a"])
+	assert.Empty(t, state.reloadQueued)
+
+	table.reloadNode(state, "a")
+	assert.Equal(t, []string{"a/b"}, state.children["a"], "children should be loaded again")
+	assert.False(t, state.loaded("a", nil), "reload should be reported only once")
+}
@@ -1,6 +1,11 @@
 package giu
 
-import "github.com/AllenDang/cimgui-go/imgui"
+import (
+	"math"
+	"sort"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
 
 var _ Widget = &ListClipperWrapper{}
 
@@ -8,12 +13,26 @@ var _ Widget = &ListClipperWrapper{}
 // it can be used to display a large, vertical list of items and
 // avoid rendering them.
 type ListClipperWrapper struct {
-	layout Layout
+	id       ID
+	layout   Layout
+	count    int
+	builder  func(i int) Widget
+	height   func(i int) float32
+	scrollTo int
 }
 
 // ListClipper creates list clipper.
 func ListClipper() *ListClipperWrapper {
-	return &ListClipperWrapper{}
+	return &ListClipperWrapper{
+		id:       GenAutoID("ListClipper"),
+		scrollTo: -1,
+	}
+}
+
+// ID sets the internal id of the list clipper (it is used by ItemHeight).
+func (l *ListClipperWrapper) ID(id ID) *ListClipperWrapper {
+	l.id = id
+	return l
 }
 
 // Layout sets layout for list clipper.
@@ -22,25 +41,185 @@ func (l *ListClipperWrapper) Layout(layout ...Widget) *ListClipperWrapper {
 	return l
 }
 
+// Items sets count of items and function creating widget of i-th item.
+// Unlike Layout, builder is called only for visible items, so the list can be very long.
+func (l *ListClipperWrapper) Items(count int, builder func(i int) Widget) *ListClipperWrapper {
+	l.count = count
+	l.builder = builder
+
+	return l
+}
+
+// ItemHeight allows items of different heights. estimate returns estimated height
+// of i-th item (without item spacing); items are measured when they are displayed.
+// Without it, all items are expected to be as high as the first one.
+func (l *ListClipperWrapper) ItemHeight(estimate func(i int) float32) *ListClipperWrapper {
+	l.height = estimate
+	return l
+}
+
+// ScrollTo scrolls the list so that i-th item is at the top.
+// Call it only in the frame when the list should scroll.
+func (l *ListClipperWrapper) ScrollTo(i int) *ListClipperWrapper {
+	l.scrollTo = i
+	return l
+}
+
+type listClipperState struct {
+	// heights are measured heights of items (including item spacing), 0 for items not displayed yet.
+	heights []float32
+	// offsets[i] is the position of i-th item from the beginning of the list.
+	offsets []float32
+	dirty   bool
+}
+
+// Dispose implements Disposable interface.
+func (s *listClipperState) Dispose() {
+	// noop
+}
+
+func (l *ListClipperWrapper) getState() *listClipperState {
+	state := GetState[listClipperState](Context, l.id)
+	if state == nil {
+		state = &listClipperState{}
+		SetState(Context, l.id, state)
+	}
+
+	return state
+}
+
 // Build implements widget interface.
 func (l *ListClipperWrapper) Build() {
-	// read all the layout widgets and (eventually) split nested layouts
-	var layout Layout
+	count, builder := l.count, l.builder
 
-	l.layout.Range(func(w Widget) {
-		layout = append(layout, w)
-	})
+	if builder == nil {
+		// read all the layout widgets and (eventually) split nested layouts
+		var layout Layout
+
+		l.layout.Range(func(w Widget) {
+			layout = append(layout, w)
+		})
+
+		count = len(layout)
+		builder = func(i int) Widget {
+			return layout[i]
+		}
+	}
+
+	if l.height != nil {
+		l.buildVariable(count, builder)
+		return
+	}
 
 	clipper := imgui.NewListClipper()
 	defer clipper.Destroy()
 
-	clipper.Begin(int32(len(layout)))
+	clipper.Begin(int32(count))
+
+	scrollTo := l.scrollTo
+	if scrollTo >= count {
+		scrollTo = -1
+	}
+
+	if scrollTo >= 0 {
+		clipper.IncludeItemByIndex(int32(scrollTo))
+	}
 
 	for clipper.Step() {
 		for i := clipper.DisplayStart(); i < clipper.DisplayEnd(); i++ {
-			layout[i].Build()
+			if int(i) == scrollTo {
+				imgui.SetScrollHereYV(0)
+			}
+
+			builder(int(i)).Build()
 		}
 	}
 
 	clipper.End()
 }
+
+// buildVariable builds items of variable heights. Positions of items are computed from
+// their measured (or estimated) heights and only visible items are built.
+func (l *ListClipperWrapper) buildVariable(count int, builder func(i int) Widget) {
+	state := l.getState()
+	spacing := imgui.CurrentStyle().ItemSpacing().Y
+
+	if len(state.heights) != count {
+		heights := make([]float32, count)
+		copy(heights, state.heights)
+		state.heights = heights
+		state.dirty = true
+	}
+
+	if state.dirty {
+		state.offsets = listClipperOffsets(state.heights, func(i int) float32 {
+			return l.height(i) + spacing
+		})
+		state.dirty = false
+	}
+
+	startY := imgui.CursorPosY()
+
+	// visible area relative to the beginning of the list
+	top := imgui.ScrollY() - startY
+
+	if l.scrollTo >= 0 && l.scrollTo < count {
+		// scroll is applied in the next frame, so display the target items now
+		top = state.offsets[l.scrollTo]
+		imgui.SetScrollYFloat(startY + top)
+	}
+
+	first, last := listClipperRange(state.offsets, top, top+imgui.WindowHeight())
+
+	imgui.SetCursorPosY(startY + state.offsets[first])
+
+	for i := first; i < last; i++ {
+		y := imgui.CursorPosY()
+
+		builder(i).Build()
+
+		height := imgui.CursorPosY() - y
+		if float32(math.Abs(float64(height-(state.offsets[i+1]-state.offsets[i])))) > 0.5 {
+			state.dirty = true
+		}
+
+		state.heights[i] = height
+	}
+
+	// extend the window to the end of the list
+	if end := startY + state.offsets[count] - spacing; end > imgui.CursorPosY() {
+		imgui.SetCursorPosY(end)
+		imgui.Dummy(imgui.Vec2{})
+	}
+}
+
+// listClipperOffsets returns positions of items (and the end of the list as the last element)
+// computed from their heights; estimate is used for items with zero height.
+func listClipperOffsets(heights []float32, estimate func(i int) float32) []float32 {
+	offsets := make([]float32, len(heights)+1)
+
+	for i, height := range heights {
+		if height == 0 {
+			height = estimate(i)
+		}
+
+		offsets[i+1] = offsets[i] + height
+	}
+
+	return offsets
+}
+
+// listClipperRange returns range [first, last) of items which are visible between top and bottom.
+func listClipperRange(offsets []float32, top, bottom float32) (first, last int) {
+	count := len(offsets) - 1
+
+	first = sort.Search(count, func(i int) bool {
+		return offsets[i+1] > top
+	})
+
+	last = sort.Search(count, func(i int) bool {
+		return offsets[i] >= bottom
+	})
+
+	return min(first, count), max(first, last)
+}
@@ -0,0 +1,38 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_listClipperOffsets(t *testing.T) {
+	offsets := listClipperOffsets([]float32{0, 30, 0}, func(i int) float32 {
+		return float32(10 * (i + 1))
+	})
+	assert.Equal(t, []float32{0, 10, 40, 70}, offsets, "measured heights should replace estimates")
+}
+
+func Test_listClipperRange(t *testing.T) {
+	offsets := []float32{0, 10, 40, 70, 80}
+
+	tests := []struct {
+		top, bottom float32
+		first, last int
+	}{
+		{0, 5, 0, 1},
+		{5, 45, 0, 3},
+		{10, 40, 1, 2},
+		{75, 200, 3, 4},
+		{100, 200, 4, 4},
+	}
+
+	for _, tt := range tests {
+		first, last := listClipperRange(offsets, tt.top, tt.bottom)
+		assert.Equal(t, tt.first, first, "first visible item in [%v, %v]", tt.top, tt.bottom)
+		assert.Equal(t, tt.last, last, "last visible item in [%v, %v]", tt.top, tt.bottom)
+	}
+
+	first, last := listClipperRange([]float32{0}, 0, 100)
+	assert.Equal(t, []int{0, 0}, []int{first, last}, "empty list")
+}
@@ -1,190 +1,677 @@
 package giu
 
 import (
-	"image"
+	"fmt"
 	"image/color"
-	"net/http"
+	"io/fs"
+	"path"
 	"strings"
 	"time"
 
-	"github.com/AllenDang/cimgui-go/backend"
 	"github.com/AllenDang/cimgui-go/imgui"
-	"github.com/AllenDang/cimgui-go/immarkdown"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
 )
 
+// markdownHeadingLevels is the number of heading levels supported by CommonMark (# to ######).
+const markdownHeadingLevels = 6
+
+// markdownHeadingScale is the default font size of each heading level relative to the default font size.
+var markdownHeadingScale = [markdownHeadingLevels]float32{2, 1.5, 1.25, 1.1, 1, 0.9}
+
+// markdownImageTimeout is a timeout of downloading images from the internet.
+const markdownImageTimeout = 10 * time.Second
+
+// markdownParser is a CommonMark parser with GitHub Flavored Markdown extensions
+// (tables, strikethrough, autolinks and task lists).
+var markdownParser = goldmark.New(goldmark.WithExtensions(extension.GFM)).Parser()
+
 type markdownState struct {
-	cfg    immarkdown.MarkdownConfig
-	images map[string]immarkdown.MarkdownImageData
+	source string
+	doc    ast.Node
+	images map[string]*StatefulReflectiveBoundTexture
 }
 
+// Dispose releases textures of all images loaded by the widget.
 func (m *markdownState) Dispose() {
-	// noop
+	for _, texture := range m.images {
+		texture.ForceRelease()
+	}
+}
+
+// markdownHeading describes how a heading of a certain level looks like.
+type markdownHeading struct {
+	font      *FontInfo
+	fontSize  float32
+	separator bool
 }
 
-// MarkdownWidget implements DearImGui markdown extension
-// https://github.com/juliettef/imgui_markdown
+// MarkdownWidget renders CommonMark-formatted text using giu widgets.
+// Apart from the CommonMark spec, GitHub Flavored Markdown extensions are supported:
+// tables, task lists, strikethrough and autolinks.
 // It is like LabelWidget but with md formatting.
 type MarkdownWidget struct {
-	md      string
-	id      ID
-	headers [3]immarkdown.MarkdownHeadingFormat
+	md       string
+	id       ID
+	headers  [markdownHeadingLevels]markdownHeading
+	codeFont *FontInfo
+	baseFS   fs.FS
+	onLink   func(url string)
+}
+
+// Markdown creates new markdown widget.
+func Markdown(md string) *MarkdownWidget {
+	return &MarkdownWidget{
+		md: md,
+		id: GenAutoID("MarkdownWidget"),
+		headers: [markdownHeadingLevels]markdownHeading{
+			{separator: true},
+			{separator: true},
+		},
+		onLink: OpenURL,
+	}
+}
+
+// ID sets the internal id of markdown widget.
+func (m *MarkdownWidget) ID(id ID) *MarkdownWidget {
+	m.id = id
+	return m
+}
+
+// OnLink sets another than default link callback.
+func (m *MarkdownWidget) OnLink(cb func(url string)) *MarkdownWidget {
+	m.onLink = cb
+	return m
+}
+
+// Header sets header formatting
+// NOTE: level (counting from 0!) is header level. (for instance, header `# H1` will have level 0).
+// NOTE: there are 6 levels (so level < 6 here). This will panic if level >= 6!
+// If font is nil, bold variant of the default font family is used (see FontAtlas.AddFontFamilyFS).
+// If fontSize is 0, it is derived from the default font size.
+func (m *MarkdownWidget) Header(level int, font *FontInfo, fontSize float32, separator bool) *MarkdownWidget {
+	// ensure level is in range
+	Assert(level >= 0 && level < markdownHeadingLevels, "MarkdownWidget", "Header", "Header level must be in range [0, %d)!", markdownHeadingLevels)
+
+	m.headers[level] = markdownHeading{
+		font:      font,
+		fontSize:  fontSize,
+		separator: separator,
+	}
+
+	return m
+}
+
+// CodeFont sets a (preferably monospace) font used for code spans and code blocks.
+func (m *MarkdownWidget) CodeFont(font *FontInfo) *MarkdownWidget {
+	m.codeFont = font
+	return m
+}
+
+// BaseFS sets a file system that relative image paths are resolved against
+// (e.g. embed.FS containing images used in the document).
+// By default, image paths are relative to the current working directory.
+func (m *MarkdownWidget) BaseFS(fsys fs.FS) *MarkdownWidget {
+	m.baseFS = fsys
+	return m
 }
 
 func (m *MarkdownWidget) getState() *markdownState {
-	if s := GetState[markdownState](Context, m.id); s != nil {
-		return s
+	source := Context.PrepareString(m.md)
+
+	state := GetState[markdownState](Context, m.id)
+	if state == nil {
+		state = &markdownState{
+			images: make(map[string]*StatefulReflectiveBoundTexture),
+		}
+
+		SetState[markdownState](Context, m.id, state)
+	}
+
+	if state.doc == nil || state.source != source {
+		state.source = source
+		state.doc = markdownParser.Parse(text.NewReader([]byte(source)))
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (m *MarkdownWidget) Build() {
+	state := m.getState()
+
+	r := &markdownRenderer{
+		widget: m,
+		state:  state,
+		source: []byte(state.source),
 	}
 
-	newState := m.newState()
-	SetState[markdownState](Context, m.id, newState)
+	r.blocks(state.doc)
+}
+
+// markdownSpan is a piece of inline content rendered with the same style.
+type markdownSpan struct {
+	text      string
+	style     FontStyle
+	code      bool
+	strike    bool
+	link      string
+	image     string
+	lineBreak bool
+}
 
-	return newState
+// markdownRenderer walks markdown AST and renders it.
+// It is created on every frame.
+type markdownRenderer struct {
+	widget   *MarkdownWidget
+	state    *markdownState
+	source   []byte
+	baseFont *FontInfo
+	nextID   int
 }
 
-func (m *MarkdownWidget) newState() *markdownState {
-	cfg := immarkdown.NewEmptyMarkdownConfig()
-	fmtCb := immarkdown.MarkdownFormalCallback(func(data *immarkdown.MarkdownFormatInfo, start bool) {
-		immarkdown.DefaultMarkdownFormatCallback(*data, start)
-	})
+func (r *markdownRenderer) genID(kind string) ID {
+	r.nextID++
+	return ID(fmt.Sprintf("##%s%s%d", r.widget.id, kind, r.nextID))
+}
 
-	cfg.SetFormatCallback(&fmtCb)
+func (r *markdownRenderer) blocks(parent ast.Node) {
+	tight := false
+	if list, ok := parent.Parent().(*ast.List); ok {
+		tight = list.IsTight
+	}
 
-	imgCb := immarkdown.MarkdownImageCallback(func(data immarkdown.MarkdownLinkCallbackData) immarkdown.MarkdownImageData {
-		link := data.Link()[:data.LinkLength()] // this is because imgui_markdown returns the whole text starting on link and returns link length (for some reason)
-		if existing, ok := m.getState().images[link]; ok {
-			return existing
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		if n.PreviousSibling() != nil && !tight {
+			imgui.Spacing()
 		}
 
-		result := mdLoadImage(link)
-		m.getState().images[link] = result
+		r.block(n)
+	}
+}
+
+func (r *markdownRenderer) block(n ast.Node) {
+	switch node := n.(type) {
+	case *ast.Heading:
+		r.heading(node)
+	case *ast.Paragraph, *ast.TextBlock:
+		r.spans(r.inlines(node, markdownSpan{}, nil))
+	case *ast.ThematicBreak:
+		imgui.Separator()
+	case *ast.CodeBlock, *ast.FencedCodeBlock, *ast.HTMLBlock:
+		r.codeBlock(node)
+	case *ast.Blockquote:
+		r.blockquote(node)
+	case *ast.List:
+		r.list(node)
+	case *east.Table:
+		r.table(node)
+	default:
+		r.blocks(node)
+	}
+}
+
+func (r *markdownRenderer) heading(n *ast.Heading) {
+	h := r.widget.headers[n.Level-1]
+
+	fontSize := h.fontSize
+	if fontSize <= 0 {
+		fontSize = imgui.CurrentStyle().FontSizeBase() * markdownHeadingScale[n.Level-1]
+	}
+
+	base := markdownSpan{}
+	if h.font == nil {
+		base.style = FontStyleBold
+	}
+
+	r.baseFont = h.font
+
+	isFontPushed := PushFont(h.font)
+	PushFontSize(fontSize)
+
+	r.spans(r.inlines(n, base, nil))
+
+	PopFont()
 
-		return result
-	})
+	if isFontPushed {
+		PopFont()
+	}
 
-	cfg.SetImageCallback(&imgCb)
+	r.baseFont = nil
 
-	return &markdownState{
-		cfg:    *cfg,
-		images: make(map[string]immarkdown.MarkdownImageData),
+	if h.separator {
+		imgui.Separator()
 	}
 }
 
-// Markdown creates new markdown widget.
-func Markdown(md string) *MarkdownWidget {
-	return (&MarkdownWidget{
-		md: md,
-		id: GenAutoID("MarkdownWidget"),
-		headers: [3]immarkdown.MarkdownHeadingFormat{
-			*immarkdown.NewEmptyMarkdownHeadingFormat(),
-			*immarkdown.NewEmptyMarkdownHeadingFormat(),
-			*immarkdown.NewEmptyMarkdownHeadingFormat(),
-		},
-	}).OnLink(OpenURL)
+func (r *markdownRenderer) codeBlock(n ast.Node) {
+	var code strings.Builder
+
+	lines := n.Lines()
+	for i := range lines.Len() {
+		segment := lines.At(i)
+		code.Write(segment.Value(r.source))
+	}
+
+	if PushFont(r.widget.codeFont) {
+		defer PopFont()
+	}
+
+	imgui.PushStyleColorVec4(imgui.ColChildBg, *imgui.StyleColorVec4(imgui.ColFrameBg))
+
+	if imgui.BeginChildStrV(
+		r.genID("code").String(),
+		imgui.Vec2{},
+		imgui.ChildFlagsAutoResizeY|imgui.ChildFlagsAlwaysUseWindowPadding,
+		imgui.WindowFlagsHorizontalScrollbar,
+	) {
+		imgui.TextUnformatted(strings.TrimSuffix(code.String(), "\n"))
+	}
+
+	imgui.EndChild()
+	imgui.PopStyleColor()
 }
 
-// OnLink sets another than default link callback.
-// NOTE: due to cimgui-go's limitation https://github.com/AllenDang/cimgui-go?tab=readme-ov-file#callbacks
-// we clear MarkdownLinkCallback pool every frame. No further action from you should be required (just feel informed).
-// ref (*MasterWindow).beforeRender.
-func (m *MarkdownWidget) OnLink(cb func(url string)) *MarkdownWidget {
-	igCb := immarkdown.MarkdownLinkCallback(func(data immarkdown.MarkdownLinkCallbackData) {
-		link := data.Link()[:data.LinkLength()]
-		cb(link)
-	})
+func (r *markdownRenderer) blockquote(n *ast.Blockquote) {
+	indent := imgui.CurrentStyle().IndentSpacing()
 
-	m.getState().cfg.SetLinkCallback(&igCb)
+	imgui.Indent()
+	imgui.BeginGroup()
+	imgui.PushStyleColorVec4(imgui.ColText, *imgui.StyleColorVec4(imgui.ColTextDisabled))
 
-	return m
+	r.blocks(n)
+
+	imgui.PopStyleColor()
+	imgui.EndGroup()
+	imgui.Unindent()
+
+	rectMin, rectMax := imgui.ItemRectMin(), imgui.ItemRectMax()
+	x := rectMin.X - indent/2
+	imgui.WindowDrawList().AddLineV(
+		imgui.Vec2{X: x, Y: rectMin.Y},
+		imgui.Vec2{X: x, Y: rectMax.Y},
+		imgui.ColorU32Col(imgui.ColBorder),
+		indent/4,
+	)
 }
 
-// Header sets header formatting
-// NOTE: level (counting from 0!) is header level. (for instance, header `# H1` will have level 0).
-// NOTE: since cimgui-go there are only 3 levels (so level < 3 here). This will panic if level >= 3!
-// TODO: it actually doesn't work.
-func (m *MarkdownWidget) Header(level int, font *FontInfo, fontSize float32, separator bool) *MarkdownWidget {
-	// ensure level is in range
-	Assert(level < 3, "MarkdownWidget", "Header", "Header level must be less than 3!")
+func (r *markdownRenderer) list(l *ast.List) {
+	index := l.Start
+
+	for item := l.FirstChild(); item != nil; item = item.NextSibling() {
+		switch checkBox := markdownTaskCheckBox(item); {
+		case checkBox != nil:
+			checked := checkBox.IsChecked
+			imgui.Checkbox(r.genID("task").String(), &checked)
+			imgui.SameLine()
+		case l.IsOrdered():
+			imgui.TextUnformatted(fmt.Sprintf("%d.", index))
+			imgui.SameLine()
+		default:
+			// imgui.Bullet calls SameLine itself
+			imgui.Bullet()
+		}
+
+		imgui.BeginGroup()
+		r.blocks(item)
+		imgui.EndGroup()
+
+		index++
+	}
+}
+
+// markdownTaskCheckBox returns a task list check box of the list item (or nil if item is not a task).
+func markdownTaskCheckBox(item ast.Node) *east.TaskCheckBox {
+	if item.FirstChild() == nil {
+		return nil
+	}
+
+	if checkBox, ok := item.FirstChild().FirstChild().(*east.TaskCheckBox); ok {
+		return checkBox
+	}
+
+	return nil
+}
+
+func (r *markdownRenderer) table(t *east.Table) {
+	header := t.FirstChild()
+	if header == nil {
+		return
+	}
+
+	columns := make([]*TableColumnWidget, 0, header.ChildCount())
+	for cell := header.FirstChild(); cell != nil; cell = cell.NextSibling() {
+		columns = append(columns, TableColumn(string(markdownPlainText(cell, r.source))))
+	}
+
+	rows := make([]*TableRowWidget, 0, t.ChildCount()-1)
+
+	for row := header.NextSibling(); row != nil; row = row.NextSibling() {
+		cells := make([]Widget, 0, len(columns))
+
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			alignment := east.AlignNone
+			if c, ok := cell.(*east.TableCell); ok {
+				alignment = c.Alignment
+			}
 
-	m.headers[level] = *immarkdown.NewEmptyMarkdownHeadingFormat()
+			spans := r.inlines(cell, markdownSpan{}, nil)
 
-	if font != nil {
-		if f, ok := Context.FontAtlas.extraFontMap[font.String()]; ok {
-			m.headers[level].SetFont(f)
+			cells = append(cells, Custom(func() {
+				r.alignSpans(spans, alignment)
+				r.spans(spans)
+			}))
 		}
+
+		rows = append(rows, TableRow(cells...))
 	}
 
-	if fontSize > 0 {
-		m.headers[level].SetFontSize(fontSize)
+	Table().
+		ID(r.genID("table")).
+		Flags(TableFlagsBorders | TableFlagsRowBg | TableFlagsSizingFixedFit).
+		Columns(columns...).
+		Rows(rows...).
+		Build()
+}
+
+// alignSpans moves cursor so that spans are aligned in the available space.
+func (r *markdownRenderer) alignSpans(spans []markdownSpan, alignment east.Alignment) {
+	if alignment != east.AlignRight && alignment != east.AlignCenter {
+		return
 	}
 
-	m.headers[level].SetSeparator(separator)
+	var width float32
 
-	state := m.getState()
-	state.cfg.SetHeadingFormats(&m.headers)
+	for _, span := range spans {
+		isFontPushed := PushFont(r.spanFont(span))
+		w, _ := CalcTextSize(span.text)
+		width += w
 
-	return m
+		if isFontPushed {
+			PopFont()
+		}
+	}
+
+	availableW, _ := GetAvailableRegion()
+	if width >= availableW {
+		return
+	}
+
+	offset := availableW - width
+	if alignment == east.AlignCenter {
+		offset /= 2
+	}
+
+	imgui.SetCursorPosX(imgui.CursorPosX() + offset)
 }
 
-// Build implements Widget interface.
-func (m *MarkdownWidget) Build() {
-	state := m.getState()
-	immarkdown.Markdown(
-		Context.PrepareString(m.md),
-		uint64(len(m.md)),
-		state.cfg,
-	)
+// inlines collects inline children of parent as a list of spans.
+// Each span inherits style of the base span.
+func (r *markdownRenderer) inlines(parent ast.Node, base markdownSpan, result []markdownSpan) []markdownSpan {
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		span := base
+
+		switch node := n.(type) {
+		case *ast.Text:
+			span.text = string(node.Value(r.source))
+			if node.SoftLineBreak() {
+				span.text += " "
+			}
+
+			result = append(result, span)
+
+			if node.HardLineBreak() {
+				result = append(result, markdownSpan{lineBreak: true})
+			}
+		case *ast.String:
+			span.text = string(node.Value)
+			result = append(result, span)
+		case *ast.CodeSpan:
+			span.code = true
+			result = r.inlines(node, span, result)
+		case *ast.Emphasis:
+			if node.Level >= 2 {
+				span.style |= FontStyleBold
+			} else {
+				span.style |= FontStyleItalic
+			}
+
+			result = r.inlines(node, span, result)
+		case *east.Strikethrough:
+			span.strike = true
+			result = r.inlines(node, span, result)
+		case *ast.Link:
+			span.link = string(node.Destination)
+			result = r.inlines(node, span, result)
+		case *ast.AutoLink:
+			span.link = string(node.URL(r.source))
+			span.text = string(node.Label(r.source))
+			result = append(result, span)
+		case *ast.Image:
+			span.image = string(node.Destination)
+			span.text = string(markdownPlainText(node, r.source))
+			result = append(result, span)
+		case *ast.RawHTML:
+			for i := range node.Segments.Len() {
+				segment := node.Segments.At(i)
+				span.text += string(segment.Value(r.source))
+			}
+
+			result = append(result, span)
+		case *east.TaskCheckBox:
+			// rendered by list
+		default:
+			result = r.inlines(node, span, result)
+		}
+	}
+
+	return result
 }
 
-func mdLoadImage(path string) immarkdown.MarkdownImageData {
-	var (
-		img *image.RGBA
-		err error
-	)
+// markdownPlainText returns text content of the node with all formatting stripped.
+func markdownPlainText(n ast.Node, source []byte) []byte {
+	var result []byte
+
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch node := c.(type) {
+		case *ast.Text:
+			result = append(result, node.Value(source)...)
+		case *ast.String:
+			result = append(result, node.Value...)
+		default:
+			result = append(result, markdownPlainText(node, source)...)
+		}
+	}
 
-	switch {
-	case strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://"):
-		// Load image from url
-		client := &http.Client{Timeout: 5 * time.Second}
+	return result
+}
+
+func (r *markdownRenderer) spanFont(span markdownSpan) *FontInfo {
+	if span.code && r.widget.codeFont != nil {
+		return r.widget.codeFont
+	}
+
+	return Context.FontAtlas.resolveFont(r.baseFont, "", span.style)
+}
+
+// spans renders spans word by word, wrapping them at the end of available region.
+func (r *markdownRenderer) spans(spans []markdownSpan) {
+	flow := newTextFlow()
+
+	for _, span := range spans {
+		if span.lineBreak {
+			flow.lineBreak()
+			continue
+		}
 
-		resp, respErr := client.Get(path)
-		if respErr != nil {
-			return *immarkdown.NewEmptyMarkdownImageData()
+		if span.image != "" && r.image(span, flow) {
+			continue
 		}
 
-		defer func() {
-			closeErr := resp.Body.Close()
-			Assert((closeErr == nil), "MarkdownWidget", "mdLoadImage", "Could not close http request!")
-		}()
+		isFontPushed := PushFont(r.spanFont(span))
 
-		rgba, _, imgErr := image.Decode(resp.Body)
-		if imgErr != nil {
-			return *immarkdown.NewEmptyMarkdownImageData()
+		for _, word := range splitWords(span.text) {
+			w, _ := CalcTextSize(word)
+			flow.next(w)
+			r.word(word, span)
 		}
 
-		img = ImageToRgba(rgba)
-	default:
-		img, err = LoadImage(path)
-		if err != nil {
-			return *immarkdown.NewEmptyMarkdownImageData()
+		if isFontPushed {
+			PopFont()
+		}
+	}
+}
+
+// word renders a single word of span.
+func (r *markdownRenderer) word(word string, span markdownSpan) {
+	drawList := imgui.WindowDrawList()
+
+	if span.code {
+		pos := imgui.CursorScreenPos()
+		w, h := CalcTextSize(word)
+		drawList.AddRectFilled(pos, imgui.Vec2{X: pos.X + w, Y: pos.Y + h}, imgui.ColorU32Col(imgui.ColFrameBg))
+	}
+
+	if span.link != "" {
+		imgui.PushStyleColorVec4(imgui.ColText, *imgui.StyleColorVec4(imgui.ColTextLink))
+	}
+
+	imgui.TextUnformatted(word)
+
+	if span.link != "" {
+		imgui.PopStyleColor()
+	}
+
+	rectMin, rectMax := imgui.ItemRectMin(), imgui.ItemRectMax()
+
+	if span.strike {
+		y := (rectMin.Y + rectMax.Y) / 2
+		drawList.AddLine(imgui.Vec2{X: rectMin.X, Y: y}, imgui.Vec2{X: rectMax.X, Y: y}, imgui.ColorU32Col(imgui.ColText))
+	}
+
+	if r.link(span.link, span.link) {
+		drawList.AddLine(
+			imgui.Vec2{X: rectMin.X, Y: rectMax.Y},
+			imgui.Vec2{X: rectMax.X, Y: rectMax.Y},
+			imgui.ColorU32Col(imgui.ColTextLink),
+		)
+	}
+}
+
+// link makes the last item behave like a link to url. It returns true if the item is hovered.
+func (r *markdownRenderer) link(url, tooltip string) bool {
+	if url == "" || !imgui.IsItemHovered() {
+		return false
+	}
+
+	imgui.SetMouseCursor(imgui.MouseCursorHand)
+
+	if tooltip != "" && imgui.BeginItemTooltip() {
+		imgui.TextUnformatted(tooltip)
+		imgui.EndTooltip()
+	}
+
+	if imgui.IsItemClicked() && r.widget.onLink != nil {
+		r.widget.onLink(url)
+	}
+
+	return true
+}
+
+// image renders an image span. While the image is loading, a spinner is shown.
+// It returns false if the image failed to load. In that case, only an error glyph
+// is rendered and the caller should render alternative text of the image.
+func (r *markdownRenderer) image(span markdownSpan, flow *textFlow) bool {
+	texture := r.state.image(span.image, r.widget.baseFS)
+	size := imgui.TextLineHeight()
+	availableW, _ := GetAvailableRegion()
+
+	switch texture.GetState() {
+	case SurfaceStateSuccess:
+		surfaceSize := texture.GetSurfaceSize()
+
+		w, h := float32(surfaceSize.X), float32(surfaceSize.Y)
+		if w > availableW {
+			w, h = availableW, h*availableW/w
+		}
+
+		flow.next(w)
+		texture.ImguiImage(w, h)
+		r.link(span.link, span.text)
+
+		return true
+	case SurfaceStateFailure:
+		flow.next(size)
+		markdownErrorGlyph(size)
+
+		if err := texture.GetLastError(); err != nil && imgui.BeginItemTooltip() {
+			imgui.TextUnformatted(err.Error())
+			imgui.EndTooltip()
 		}
+
+		return false
+	default:
+		size *= 2
+
+		flow.next(size)
+		ProgressIndicator("", size, size, size/3).Build()
+
+		return true
+	}
+}
+
+// markdownErrorGlyph draws a crossed box of the given size.
+func markdownErrorGlyph(size float32) {
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(imgui.Vec2{X: size, Y: size})
+
+	drawList := imgui.WindowDrawList()
+	col := ColorToUint(color.RGBA{R: 230, G: 70, B: 70, A: 255})
+	padding := size / 4
+	end := imgui.Vec2{X: pos.X + size, Y: pos.Y + size}
+
+	drawList.AddRect(pos, end, col)
+	drawList.AddLine(
+		imgui.Vec2{X: pos.X + padding, Y: pos.Y + padding},
+		imgui.Vec2{X: end.X - padding, Y: end.Y - padding},
+		col,
+	)
+	drawList.AddLine(
+		imgui.Vec2{X: end.X - padding, Y: pos.Y + padding},
+		imgui.Vec2{X: pos.X + padding, Y: end.Y - padding},
+		col,
+	)
+}
+
+// image returns a texture of the image at src. If the image is requested for the first time,
+// it starts loading it asynchronously.
+func (m *markdownState) image(src string, baseFS fs.FS) *StatefulReflectiveBoundTexture {
+	if texture, ok := m.images[src]; ok {
+		return texture
 	}
 
-	size := img.Bounds()
-	id := backend.NewTextureFromRgba(img).ID
+	texture := &StatefulReflectiveBoundTexture{}
+	texture.OnSuccess(Update).OnFailure(func(error) { Update() })
+	m.images[src] = texture
 
-	result := immarkdown.NewEmptyMarkdownImageData()
-	result.SetUsertextureid(id.TexID())
-	result.SetSize(imgui.Vec2{
-		X: float32(size.Dx()),
-		Y: float32(size.Dy()),
-	})
-	result.SetUseLinkCallback(true)
-	result.SetUv0(ToVec2(image.Point{0, 0}))
-	result.SetUv1(ToVec2(image.Point{1, 1}))
-	result.SetTintcol(ToVec4Color(color.RGBA{255, 255, 255, 255}))
-	result.SetBordercol(ToVec4Color(color.RGBA{0, 0, 0, 0}))
+	// commit is false, because texture must be created on the main thread (it is done by ImguiImage).
+	err := texture.LoadSurfaceAsync(markdownImageLoader(src, baseFS), false)
+	Assert(err == nil, "MarkdownWidget", "image", "unexpected error while loading image: %v", err)
 
-	result.SetIsValid(true)
+	return texture
+}
 
-	return *result
+// markdownImageLoader returns a SurfaceLoader suitable for image source src.
+func markdownImageLoader(src string, baseFS fs.FS) SurfaceLoader {
+	switch {
+	case strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://"):
+		return NewURLLoader(src, "", markdownImageTimeout)
+	case baseFS != nil:
+		return NewFSLoader(baseFS, strings.TrimPrefix(path.Clean(src), "/"))
+	default:
+		return NewFileLoader(src)
+	}
 }
@@ -0,0 +1,38 @@
+package giu
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark/text"
+)
+
+func Test_markdownRenderer_inlines(t *testing.T) {
+	source := []byte("plain **bold *both*** `code` ~~gone~~ [link](https://example.com)")
+	doc := markdownParser.Parse(text.NewReader(source))
+	r := &markdownRenderer{source: source}
+
+	spans := r.inlines(doc.FirstChild(), markdownSpan{}, nil)
+
+	assert.Equal(t, []markdownSpan{
+		{text: "plain "},
+		{text: "bold ", style: FontStyleBold},
+		{text: "both", style: FontStyleBoldItalic},
+		{text: " "},
+		{text: "code", code: true},
+		{text: " "},
+		{text: "gone", strike: true},
+		{text: " "},
+		{text: "link", link: "https://example.com"},
+	}, spans)
+}
+
+func Test_markdownImageLoader(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	assert.IsType(t, &URLLoader{}, markdownImageLoader("https://example.com/a.png", fsys))
+	assert.IsType(t, &FileLoader{}, markdownImageLoader("./a.png", nil))
+	assert.Equal(t, NewFSLoader(fsys, "img/a.png"), markdownImageLoader("./img/../img/a.png", fsys))
+	assert.Equal(t, NewFSLoader(fsys, "a.png"), markdownImageLoader("/a.png", fsys))
+}
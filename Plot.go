@@ -1,8 +1,11 @@
 package giu
 
 import (
+	"fmt"
 	"image"
+	"image/color"
 
+	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/AllenDang/cimgui-go/implot"
 	"github.com/AllenDang/cimgui-go/utils"
 )
@@ -12,6 +15,8 @@ type (
 	PlotXAxis = implot.AxisEnum
 	// PlotYAxis allows to chose Y axis.
 	PlotYAxis = implot.AxisEnum
+	// PlotAxis allows to chose any (X or Y) axis.
+	PlotAxis = implot.AxisEnum
 )
 
 // Available axes.
@@ -40,6 +45,89 @@ const (
 	ImPlotYAxisSecondOnRight ImPlotYAxis = 2 // second on right side
 )
 
+// axis returns implot's y axis corresponding to a.
+func (a ImPlotYAxis) axis() implot.AxisEnum {
+	return implot.AxisY1 + implot.AxisEnum(a)
+}
+
+// setPlotYAxis makes the next plot item use yAxis and returns axes of the item.
+// Axes set by implot.SetAxis stay set for the following items, so they are always set:
+// items on the default (left) axis use the axes chosen by SwitchPlotAxes.
+func setPlotYAxis(yAxis ImPlotYAxis) (x, y PlotAxis) {
+	x, y = AxisX1, AxisY1
+	if currentPlotCanvas != nil {
+		x, y = currentPlotCanvas.itemXAxis, currentPlotCanvas.itemYAxis
+	}
+
+	x, y = plotItemAxes(yAxis, x, y)
+	implot.SetAxes(x, y)
+
+	return x, y
+}
+
+// plotItemAxes returns axes of an item plotted on yAxis when defaultX and defaultY are set by SwitchPlotAxes.
+func plotItemAxes(yAxis ImPlotYAxis, defaultX, defaultY PlotAxis) (x, y PlotAxis) {
+	if yAxis == ImPlotYAxisLeft {
+		return defaultX, defaultY
+	}
+
+	return defaultX, yAxis.axis()
+}
+
+// PlotBins represents a number of histogram bins.
+// Positive values are an exact number of bins, negative values select a method
+// of computing it from the data.
+type PlotBins int32
+
+// Histogram bin methods.
+const (
+	// PlotBinsSqrt uses k = sqrt(n) bins.
+	PlotBinsSqrt PlotBins = PlotBins(implot.BinSqrt)
+	// PlotBinsSturges uses k = 1 + log2(n) bins (default).
+	PlotBinsSturges PlotBins = PlotBins(implot.BinSturges)
+	// PlotBinsRice uses k = 2 * cbrt(n) bins.
+	PlotBinsRice PlotBins = PlotBins(implot.BinRice)
+	// PlotBinsScott uses bin width h = 3.49 * sigma / cbrt(n).
+	PlotBinsScott PlotBins = PlotBins(implot.BinScott)
+)
+
+// PlotColormap represents implot.Colormap.
+type PlotColormap implot.Colormap
+
+// Built-in colormaps.
+const (
+	// PlotColormapDefault keeps the current colormap.
+	PlotColormapDefault  PlotColormap = -1
+	PlotColormapDeep     PlotColormap = PlotColormap(implot.ColormapDeep)
+	PlotColormapDark     PlotColormap = PlotColormap(implot.ColormapDark)
+	PlotColormapPastel   PlotColormap = PlotColormap(implot.ColormapPastel)
+	PlotColormapPaired   PlotColormap = PlotColormap(implot.ColormapPaired)
+	PlotColormapViridis  PlotColormap = PlotColormap(implot.ColormapViridis)
+	PlotColormapPlasma   PlotColormap = PlotColormap(implot.ColormapPlasma)
+	PlotColormapHot      PlotColormap = PlotColormap(implot.ColormapHot)
+	PlotColormapCool     PlotColormap = PlotColormap(implot.ColormapCool)
+	PlotColormapPink     PlotColormap = PlotColormap(implot.ColormapPink)
+	PlotColormapJet      PlotColormap = PlotColormap(implot.ColormapJet)
+	PlotColormapTwilight PlotColormap = PlotColormap(implot.ColormapTwilight)
+	PlotColormapRdBu     PlotColormap = PlotColormap(implot.ColormapRdBu)
+	PlotColormapBrBG     PlotColormap = PlotColormap(implot.ColormapBrBG)
+	PlotColormapPiYG     PlotColormap = PlotColormap(implot.ColormapPiYG)
+	PlotColormapSpectral PlotColormap = PlotColormap(implot.ColormapSpectral)
+	PlotColormapGreys    PlotColormap = PlotColormap(implot.ColormapGreys)
+)
+
+// push pushes colormap to the implot's stack (if not default).
+// It returns true if Pop needs to be called.
+func (c PlotColormap) push() bool {
+	if c == PlotColormapDefault {
+		return false
+	}
+
+	implot.PushColormapPlotColormap(implot.Colormap(c))
+
+	return true
+}
+
 // PlotTicker represents axis ticks.
 type PlotTicker struct {
 	Position float64
@@ -48,138 +136,79 @@ type PlotTicker struct {
 
 // PlotCanvasWidget represents a giu plot widget.
 type PlotCanvasWidget struct {
-	title                            string
-	xLabel                           string
-	yLabel                           string
-	width                            int
-	height                           int
-	flags                            PlotFlags
-	xFlags, yFlags, y2Flags, y3Flags PlotAxisFlags
-	xScale, yScale, y2Scale, y3Scale PlotScale
-	y2Label                          string
-	y3Label                          string
-	xMin, xMax, yMin, yMax           float64
-	axisLimitCondition               ExecCondition
-	xTicksValue, yTicksValue         []float64
-	xTicksLabel, yTicksLabel         []string
-	xTicksShowDefault                bool
-	yTicksShowDefault                bool
-	yTicksYAxis                      ImPlotYAxis
-	plots                            []PlotWidget
+	title          string
+	width          int
+	height         int
+	flags          PlotFlags
+	axes           [implot.AxisCOUNT]plotAxis
+	plots          []PlotWidget
+	onSelect       func(selection PlotRect)
+	axisLinks      map[implot.AxisEnum]*PlotRange
+	timeFormat     plotTimeFormat
+	id             ID
+	legendLocation PlotLocation
+	legendFlags    PlotLegendFlags
+	onLegendToggle func(label string, visible bool)
+	onExport       func(format PlotExportFormat, data []byte, err error)
+
+	// itemXAxis and itemYAxis are axes set by SwitchPlotAxes while the plots are built.
+	itemXAxis, itemYAxis PlotAxis
 }
 
 // Plot adds creates a new plot widget.
 func Plot(title string) *PlotCanvasWidget {
-	return &PlotCanvasWidget{
-		title:              title,
-		xLabel:             "",
-		yLabel:             "",
-		width:              -1,
-		height:             0,
-		flags:              PlotFlagsNone,
-		xFlags:             PlotAxisFlagsNone,
-		yFlags:             PlotAxisFlagsNone,
-		y2Flags:            PlotAxisFlagsNoGridLines,
-		y3Flags:            PlotAxisFlagsNoGridLines,
-		xScale:             PlotScaleLinear,
-		yScale:             PlotScaleLinear,
-		y2Scale:            PlotScaleLinear,
-		y3Scale:            PlotScaleLinear,
-		y2Label:            "",
-		y3Label:            "",
-		xMin:               0,
-		xMax:               10,
-		yMin:               0,
-		yMax:               10,
-		xTicksShowDefault:  true,
-		yTicksShowDefault:  true,
-		yTicksYAxis:        0,
-		axisLimitCondition: ConditionOnce,
+	iso8601, clock24 := systemTimeFormat()
+
+	p := &PlotCanvasWidget{
+		title:          title,
+		width:          -1,
+		height:         0,
+		flags:          PlotFlagsNone,
+		timeFormat:     plotTimeFormat{iso8601: iso8601, clock24: clock24},
+		id:             GenAutoID("Plot"),
+		legendLocation: PlotLocationNorthWest,
 	}
+
+	for _, axis := range []PlotAxis{AxisX2, AxisX3, AxisY2, AxisY3} {
+		p.axes[axis].flags = PlotAxisFlagsNoGridLines
+	}
+
+	return p.AxisLimits(0, 10, 0, 10, ConditionOnce)
 }
 
 // SetXAxisLabel sets x axis label.
 func (p *PlotCanvasWidget) SetXAxisLabel(axis PlotXAxis, label string) *PlotCanvasWidget {
-	switch axis {
-	case AxisX1:
-		p.xLabel = label
-	case AxisX2:
-		p.y2Label = label
-	case AxisX3:
-		p.y3Label = label
-	}
-
+	p.axes[axis].label = label
 	return p
 }
 
 // SetYAxisLabel sets y axis label.
 func (p *PlotCanvasWidget) SetYAxisLabel(axis PlotYAxis, label string) *PlotCanvasWidget {
-	switch axis {
-	case AxisY1:
-		p.yLabel = label
-	case AxisY2:
-		p.y2Label = label
-	case AxisY3:
-		p.y3Label = label
-	}
-
+	p.axes[axis].label = label
 	return p
 }
 
 // AxisLimits sets X and Y axis limits.
 func (p *PlotCanvasWidget) AxisLimits(xmin, xmax, ymin, ymax float64, cond ExecCondition) *PlotCanvasWidget {
-	p.xMin = xmin
-	p.xMax = xmax
-	p.yMin = ymin
-	p.yMax = ymax
-	p.axisLimitCondition = cond
-
-	return p
+	return p.SetAxisLimits(AxisX1, xmin, xmax, cond).SetAxisLimits(AxisY1, ymin, ymax, cond)
 }
 
 // XTicks sets x axis ticks.
 func (p *PlotCanvasWidget) XTicks(ticks []PlotTicker, showDefault bool) *PlotCanvasWidget {
-	length := len(ticks)
-	if length == 0 {
+	if len(ticks) == 0 {
 		return p
 	}
 
-	values := make([]float64, length)
-	labels := make([]string, length)
-
-	for i, t := range ticks {
-		values[i] = t.Position
-		labels[i] = t.Label
-	}
-
-	p.xTicksValue = values
-	p.xTicksLabel = labels
-	p.xTicksShowDefault = showDefault
-
-	return p
+	return p.SetAxisTicks(AxisX1, ticks, showDefault)
 }
 
 // YTicks sets y axis ticks.
 func (p *PlotCanvasWidget) YTicks(ticks []PlotTicker, showDefault bool, yAxis ImPlotYAxis) *PlotCanvasWidget {
-	length := len(ticks)
-	if length == 0 {
+	if len(ticks) == 0 {
 		return p
 	}
 
-	values := make([]float64, length)
-	labels := make([]string, length)
-
-	for i, t := range ticks {
-		values[i] = t.Position
-		labels[i] = t.Label
-	}
-
-	p.yTicksValue = values
-	p.yTicksLabel = labels
-	p.yTicksShowDefault = showDefault
-	p.yTicksYAxis = yAxis
-
-	return p
+	return p.SetAxisTicks(yAxis.axis(), ticks, showDefault)
 }
 
 // Flags sets plot canvas flags.
@@ -190,40 +219,61 @@ func (p *PlotCanvasWidget) Flags(flags PlotFlags) *PlotCanvasWidget {
 
 // XAxeFlags sets x axis fags.
 func (p *PlotCanvasWidget) XAxeFlags(flags PlotAxisFlags) *PlotCanvasWidget {
-	p.xFlags = flags
+	p.axes[AxisX1].flags = flags
 	return p
 }
 
 // YAxeFlags sets y axis flags.
 func (p *PlotCanvasWidget) YAxeFlags(yFlags, y2Flags, y3Flags PlotAxisFlags) *PlotCanvasWidget {
-	p.yFlags = yFlags
-	p.y2Flags = y2Flags
-	p.y3Flags = y3Flags
+	p.axes[AxisY1].flags = yFlags
+	p.axes[AxisY2].flags = y2Flags
+	p.axes[AxisY3].flags = y3Flags
 
 	return p
 }
 
 // XScale sets the plot x axis scale.
 func (p *PlotCanvasWidget) XScale(scale PlotScale) *PlotCanvasWidget {
-	p.xScale = scale
+	p.axes[AxisX1].scale = scale
 	return p
 }
 
 // YScale sets the plot y axis scale.
 func (p *PlotCanvasWidget) YScale(scale PlotScale) *PlotCanvasWidget {
-	p.yScale = scale
+	p.axes[AxisY1].scale = scale
 	return p
 }
 
 // Y2Scale sets the plot y2 axis scale.
 func (p *PlotCanvasWidget) Y2Scale(scale PlotScale) *PlotCanvasWidget {
-	p.y2Scale = scale
+	p.axes[AxisY2].scale = scale
 	return p
 }
 
 // Y3Scale sets the plot y3 axis scale.
 func (p *PlotCanvasWidget) Y3Scale(scale PlotScale) *PlotCanvasWidget {
-	p.y3Scale = scale
+	p.axes[AxisY3].scale = scale
+	return p
+}
+
+// TimeAxis makes x axis a time axis (see PlotScaleTime).
+// Values on the axis are Unix times in seconds (see TimeToPlotValue and LineTime).
+// Density and format of ticks change with the zoom level.
+// If local is true, times are displayed in the local time zone, otherwise in UTC.
+// Date and clock conventions follow the system locale unless set by TimeFormat.
+func (p *PlotCanvasWidget) TimeAxis(local bool) *PlotCanvasWidget {
+	p.axes[AxisX1].scale = PlotScaleTime
+	p.timeFormat.local = local
+
+	return p
+}
+
+// TimeFormat sets date (ISO 8601 or month/day) and clock (24-hour or 12-hour) conventions
+// of the time axis.
+func (p *PlotCanvasWidget) TimeFormat(iso8601, clock24 bool) *PlotCanvasWidget {
+	p.timeFormat.iso8601 = iso8601
+	p.timeFormat.clock24 = clock24
+
 	return p
 }
 
@@ -233,6 +283,56 @@ func (p *PlotCanvasWidget) Plots(plots ...PlotWidget) *PlotCanvasWidget {
 	return p
 }
 
+// LinkXAxis links x axis with r. All the axes linked with the same PlotRange
+// are panned and zoomed together.
+func (p *PlotCanvasWidget) LinkXAxis(axis PlotXAxis, r *PlotRange) *PlotCanvasWidget {
+	return p.linkAxis(axis, r)
+}
+
+// LinkYAxis links y axis with r. All the axes linked with the same PlotRange
+// are panned and zoomed together.
+func (p *PlotCanvasWidget) LinkYAxis(axis PlotYAxis, r *PlotRange) *PlotCanvasWidget {
+	return p.linkAxis(axis, r)
+}
+
+func (p *PlotCanvasWidget) linkAxis(axis implot.AxisEnum, r *PlotRange) *PlotCanvasWidget {
+	if p.axisLinks == nil {
+		p.axisLinks = make(map[implot.AxisEnum]*PlotRange)
+	}
+
+	p.axisLinks[axis] = r
+
+	return p
+}
+
+// OnSelect sets callback called when user finishes box selection (dragging with right mouse button by default).
+// It receives selected area of the primary axes (X1, Y1). The selection is cleared afterwards.
+func (p *PlotCanvasWidget) OnSelect(onSelect func(selection PlotRect)) *PlotCanvasWidget {
+	p.onSelect = onSelect
+	return p
+}
+
+// ID sets the internal id of the canvas (used to store its state).
+func (p *PlotCanvasWidget) ID(id ID) *PlotCanvasWidget {
+	p.id = id
+	return p
+}
+
+// Legend sets legend's location and flags.
+// Use PlotLegendFlagsHorizontal and PlotLegendFlagsOutside to change its orientation and placement.
+func (p *PlotCanvasWidget) Legend(location PlotLocation, flags PlotLegendFlags) *PlotCanvasWidget {
+	p.legendLocation = location
+	p.legendFlags = flags
+
+	return p
+}
+
+// OnLegendToggle sets callback called when the user shows or hides a series by clicking its legend entry.
+func (p *PlotCanvasWidget) OnLegendToggle(onToggle func(label string, visible bool)) *PlotCanvasWidget {
+	p.onLegendToggle = onToggle
+	return p
+}
+
 // Size set canvas size.
 func (p *PlotCanvasWidget) Size(width, height int) *PlotCanvasWidget {
 	p.width = width
@@ -247,318 +347,438 @@ func (p *PlotCanvasWidget) Build() {
 		return
 	}
 
+	p.build()
+}
+
+// build builds the canvas even if it has no plots (in subplots, each canvas takes a cell of the grid).
+func (p *PlotCanvasWidget) build() {
+	if p.axes[AxisX1].scale == PlotScaleTime {
+		defer p.timeFormat.push()()
+	}
+
 	if implot.BeginPlotV(
 		Context.PrepareString(p.title),
 		ToVec2(image.Pt(p.width, p.height)),
 		implot.Flags(p.flags),
 	) {
-		implot.SetupAxisScalePlotScale(
-			implot.AxisX1,
-			implot.Scale(p.xScale),
-		)
-		implot.SetupAxisScalePlotScale(
-			implot.AxisY1,
-			implot.Scale(p.yScale),
-		)
-
-		if p.y2Label != "" {
-			implot.SetupAxisScalePlotScale(
-				implot.AxisY2,
-				implot.Scale(p.y2Scale),
-			)
+		for axis := range implot.AxisCOUNT {
+			p.setupAxis(axis)
 		}
 
-		if p.y3Label != "" {
-			implot.SetupAxisScalePlotScale(
-				implot.AxisY3,
-				implot.Scale(p.y3Scale),
-			)
-		}
+		implot.SetupLegendV(implot.Location(p.legendLocation), implot.LegendFlags(p.legendFlags))
 
-		implot.SetupAxisLimitsV(
-			implot.AxisX1,
-			p.xMin,
-			p.xMax,
-			implot.Cond(p.axisLimitCondition),
-		)
-		implot.SetupAxisLimitsV(
-			implot.AxisY1,
-			p.yMin,
-			p.yMax,
-			implot.Cond(p.axisLimitCondition),
-		)
+		prevCanvas := currentPlotCanvas
+		currentPlotCanvas = p
+		p.itemXAxis, p.itemYAxis = AxisX1, AxisY1
 
-		if len(p.xTicksValue) > 0 {
-			implot.SetupAxisTicksdoublePtrV(
-				implot.AxisX1,
-				utils.SliceToPtr(p.xTicksValue),
-				int32(len(p.xTicksValue)),
-				p.xTicksLabel,
-				p.xTicksShowDefault,
-			)
+		for _, plot := range p.plots {
+			plot.Plot()
 		}
 
-		if len(p.yTicksValue) > 0 {
-			implot.SetupAxisTicksdoublePtrV(
-				implot.AxisY1,
-				utils.SliceToPtr(p.yTicksValue),
-				int32(len(p.yTicksValue)),
-				p.yTicksLabel,
-				p.yTicksShowDefault,
-			)
-		}
+		currentPlotCanvas = prevCanvas
 
-		implot.SetupAxisV(
-			implot.AxisX1,
-			Context.PrepareString(p.xLabel),
-			implot.AxisFlags(p.xFlags),
-		)
+		p.handleSelection()
+		p.recordFrame()
 
-		implot.SetupAxisV(
-			implot.AxisY1,
-			Context.PrepareString(p.yLabel),
-			implot.AxisFlags(p.yFlags),
-		)
+		plotID := implot.GetCurrentPlot().ID()
 
-		if p.y2Label != "" {
-			implot.SetupAxisV(
-				implot.AxisY2,
-				Context.PrepareString(p.y2Label),
-				implot.AxisFlags(p.y2Flags),
-			)
-		}
+		implot.EndPlot()
 
-		if p.y3Label != "" {
-			implot.SetupAxisV(
-				implot.AxisY3,
-				Context.PrepareString(p.y3Label),
-				implot.AxisFlags(p.y3Flags),
-			)
+		state := p.getState()
+		state.frameSize = plotFrameSize()
+		p.requestCapture(state)
+
+		if p.onExport != nil {
+			p.buildExportMenu(plotID)
 		}
+	} else if state := p.getState(); state.capture != nil {
+		onCapture := state.capture
+		state.capture = nil
 
-		for _, plot := range p.plots {
-			plot.Plot()
+		onCapture(nil, fmt.Errorf("%w: plot is not visible", ErrFrameNotCaptured))
+	}
+}
+
+func (p *PlotCanvasWidget) getState() (state *plotCanvasState) {
+	if state = GetState[plotCanvasState](Context, p.id); state == nil {
+		state = &plotCanvasState{
+			visible: make(map[string]bool),
+			colors:  make(map[string]color.NRGBA),
 		}
+		SetState(Context, p.id, state)
+	}
 
-		implot.EndPlot()
+	return state
+}
+
+// handleSelection calls onSelect when box selection is finished.
+func (p *PlotCanvasWidget) handleSelection() {
+	if p.onSelect == nil || !implot.IsPlotSelected() || IsMouseDown(MouseButton(implot.GetInputMap().Select())) {
+		return
 	}
+
+	selection := implot.GetPlotSelectionV(implot.AxisX1, implot.AxisY1)
+	xRange, yRange := selection.X(), selection.Y()
+
+	p.onSelect(PlotRect{
+		XMin: xRange.Min(),
+		XMax: xRange.Max(),
+		YMin: yRange.Min(),
+		YMax: yRange.Max(),
+	})
+
+	implot.CancelPlotSelection()
 }
 
-// SwitchPlotAxes switches plot axes.
+// SwitchPlotAxes switches axes of the following plots (except of plots with explicitly set y axis).
 func SwitchPlotAxes(x PlotXAxis, y PlotYAxis) PlotWidget {
 	return Custom(func() {
+		if currentPlotCanvas != nil {
+			currentPlotCanvas.itemXAxis, currentPlotCanvas.itemYAxis = x, y
+		}
+
 		implot.SetAxes(x, y)
 	})
 }
 
-// BarPlot adds bar plot (column chart) to the canvas.
-type BarPlot struct {
+// BarPlotOf adds bar plot (column chart) of values of any numeric type to the canvas.
+type BarPlotOf[T PlotNumber] struct {
 	title  string
-	data   []float64
+	data   []T
+	xs     []T
 	width  float64
 	shift  float64
 	offset int
+	yAxis  ImPlotYAxis
+
+	plotSeries[*BarPlotOf[T]]
 }
 
-// Bar adds plot bars to the canvas.
-func Bar(title string, data []float64) *BarPlot {
-	return &BarPlot{
+// BarPlot adds bar plot (column chart) to the canvas.
+type BarPlot = BarPlotOf[float64]
+
+// BarOf adds plot bars of any numeric type to the canvas.
+func BarOf[T PlotNumber](title string, data []T) *BarPlotOf[T] {
+	p := &BarPlotOf[T]{
 		title:  title,
 		data:   data,
 		width:  0.2,
 		shift:  0,
 		offset: 0,
 	}
+	p.self = p
+
+	return p
+}
+
+// Bar adds plot bars to the canvas.
+func Bar(title string, data []float64) *BarPlot {
+	return BarOf(title, data)
 }
 
 // Width sets bar width.
-func (p *BarPlot) Width(width float64) *BarPlot {
+func (p *BarPlotOf[T]) Width(width float64) *BarPlotOf[T] {
 	p.width = width
 	return p
 }
 
 // Shift sets shift of the bar.
-func (p *BarPlot) Shift(shift float64) *BarPlot {
+func (p *BarPlotOf[T]) Shift(shift float64) *BarPlotOf[T] {
 	p.shift = shift
 	return p
 }
 
 // Offset sets bar's offset.
-func (p *BarPlot) Offset(offset int) *BarPlot {
+func (p *BarPlotOf[T]) Offset(offset int) *BarPlotOf[T] {
 	p.offset = offset
 	return p
 }
 
+// XValues places bars at given x positions (e.g. dates of a Candlestick) instead of 0, 1, 2...
+// Shift is ignored then.
+func (p *BarPlotOf[T]) XValues(xs []T) *BarPlotOf[T] {
+	p.xs = xs
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *BarPlotOf[T]) SetPlotYAxis(yAxis ImPlotYAxis) *BarPlotOf[T] {
+	p.yAxis = yAxis
+	return p
+}
+
 // Plot implements Plot interface.
-func (p *BarPlot) Plot() {
-	implot.PlotBarsdoublePtrIntV(
-		p.title,
-		utils.SliceToPtr(p.data),
-		int32(len(p.data)),
+func (p *BarPlotOf[T]) Plot() {
+	defer p.style.setNext(p.title)()
+
+	setPlotYAxis(p.yAxis)
+
+	if p.xs != nil {
+		plotBarsXY(Context.PrepareString(p.title), p.xs, p.data, p.width, 0, p.offset)
+		return
+	}
+
+	plotBarsValues(
+		Context.PrepareString(p.title),
+		p.data,
 		p.width,
 		p.shift,
 		0, // TODO: implement
-		int32(p.offset),
-		8, // in fact this is sizeof(double) = 8
+		p.offset,
+		plotStride[T](),
 	)
 }
 
-// BarHPlot represents a column chart on Y axis.
-type BarHPlot struct {
+// BarHPlotOf represents a column chart on Y axis of values of any numeric type.
+type BarHPlotOf[T PlotNumber] struct {
 	title  string
-	data   []float64
+	data   []T
 	height float64
 	shift  float64
 	offset int
+
+	plotSeries[*BarHPlotOf[T]]
 }
 
-// BarH adds plot bars on y axis.
-func BarH(title string, data []float64) *BarHPlot {
-	return &BarHPlot{
+// BarHPlot represents a column chart on Y axis.
+type BarHPlot = BarHPlotOf[float64]
+
+// BarHOf adds plot bars of any numeric type on y axis.
+func BarHOf[T PlotNumber](title string, data []T) *BarHPlotOf[T] {
+	p := &BarHPlotOf[T]{
 		title:  title,
 		data:   data,
 		height: 0.2,
 		shift:  0,
 		offset: 0,
 	}
+	p.self = p
+
+	return p
+}
+
+// BarH adds plot bars on y axis.
+func BarH(title string, data []float64) *BarHPlot {
+	return BarHOf(title, data)
 }
 
 // Height sets bar height (in fact bars' width).
-func (p *BarHPlot) Height(height float64) *BarHPlot {
+func (p *BarHPlotOf[T]) Height(height float64) *BarHPlotOf[T] {
 	p.height = height
 	return p
 }
 
 // Shift sets shift.
-func (p *BarHPlot) Shift(shift float64) *BarHPlot {
+func (p *BarHPlotOf[T]) Shift(shift float64) *BarHPlotOf[T] {
 	p.shift = shift
 	return p
 }
 
 // Offset sets offset.
-func (p *BarHPlot) Offset(offset int) *BarHPlot {
+func (p *BarHPlotOf[T]) Offset(offset int) *BarHPlotOf[T] {
 	p.offset = offset
 	return p
 }
 
 // Plot implements plot interface.
-func (p *BarHPlot) Plot() {
-	implot.PlotBarsdoublePtrIntV(
+func (p *BarHPlotOf[T]) Plot() {
+	defer p.style.setNext(p.title)()
+
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	plotBarsValues(
 		Context.PrepareString(p.title),
-		utils.SliceToPtr(p.data),
-		int32(len(p.data)),
+		p.data,
 		p.height,
 		p.shift,
 		implot.BarsFlagsHorizontal,
-		int32(p.offset),
-		0,
+		p.offset,
+		plotStride[T](),
 	)
 }
 
-// LinePlot represents a plot line (linear chart).
-type LinePlot struct {
+// LinePlotOf represents a plot line (linear chart) of values of any numeric type.
+type LinePlotOf[T PlotNumber] struct {
 	title      string
-	values     []float64
+	values     []T
 	xScale, x0 float64
 	offset     int
 	yAxis      ImPlotYAxis
+	buffer     *PlotRingBuffer
+
+	plotHoverSeries[*LinePlotOf[T]]
 }
 
-// Line adds a new plot line to the canvas.
-func Line(title string, values []float64) *LinePlot {
-	return &LinePlot{
+// LinePlot represents a plot line (linear chart).
+type LinePlot = LinePlotOf[float64]
+
+// LineOf adds a new plot line of values of any numeric type to the canvas.
+// Values are passed to implot without conversion.
+func LineOf[T PlotNumber](title string, values []T) *LinePlotOf[T] {
+	p := &LinePlotOf[T]{
 		title:  title,
 		values: values,
 		xScale: 1,
 		x0:     0,
 		offset: 0,
 	}
+	p.self = p
+
+	return p
+}
+
+// Line adds a new plot line to the canvas.
+func Line(title string, values []float64) *LinePlot {
+	return LineOf(title, values)
 }
 
 // SetPlotYAxis sets yAxis parameters.
-func (p *LinePlot) SetPlotYAxis(yAxis ImPlotYAxis) *LinePlot {
+func (p *LinePlotOf[T]) SetPlotYAxis(yAxis ImPlotYAxis) *LinePlotOf[T] {
 	p.yAxis = yAxis
 	return p
 }
 
 // XScale sets x-axis-scale.
-func (p *LinePlot) XScale(scale float64) *LinePlot {
+func (p *LinePlotOf[T]) XScale(scale float64) *LinePlotOf[T] {
 	p.xScale = scale
 	return p
 }
 
 // X0 sets a start position on x axis.
-func (p *LinePlot) X0(x0 float64) *LinePlot {
+func (p *LinePlotOf[T]) X0(x0 float64) *LinePlotOf[T] {
 	p.x0 = x0
 	return p
 }
 
 // Offset sets chart offset.
-func (p *LinePlot) Offset(offset int) *LinePlot {
+func (p *LinePlotOf[T]) Offset(offset int) *LinePlotOf[T] {
 	p.offset = offset
 	return p
 }
 
+// x returns x position of i-th value.
+func (p *LinePlotOf[T]) x(i int) float64 {
+	return p.x0 + float64(i)*p.xScale
+}
+
 // Plot implements Plot interface.
-func (p *LinePlot) Plot() {
-	implot.SetAxis(
-		implot.AxisEnum(p.yAxis),
-	)
+func (p *LinePlotOf[T]) Plot() {
+	defer p.style.setNext(p.title)()
+
+	setPlotYAxis(p.yAxis)
+
+	if p.buffer != nil {
+		p.plotBuffer()
+		return
+	}
 
-	implot.PlotLinedoublePtrIntV(
+	plotLineValues(
 		Context.PrepareString(p.title),
-		utils.SliceToPtr(p.values),
-		int32(len(p.values)),
+		p.values,
 		p.xScale,
 		p.x0,
 		0, // flags
-		int32(p.offset),
-		8, // in fact this is sizeof(double) = 8
+		p.offset,
+		plotStride[T](),
 	)
+
+	plotHover(p.onHover, len(p.values), p.x, func(i int) float64 {
+		return float64(p.values[(p.offset+i)%len(p.values)])
+	})
 }
 
-// LineXYPlot adds XY plot line.
-type LineXYPlot struct {
+// plotBuffer plots y values of the ring buffer.
+func (p *LinePlotOf[T]) plotBuffer() {
+	b := p.buffer
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if xs, ys, ok := b.decimate(p.x); ok {
+		plotLineXY(Context.PrepareString(p.title), xs, ys, 0, 0)
+	} else {
+		plotLineValues(Context.PrepareString(p.title), b.ys, p.xScale, p.x0, 0, b.start, plotStride[float64]())
+	}
+
+	plotHover(p.onHover, len(b.ys), p.x, func(i int) float64 {
+		return b.ys[b.index(i)]
+	})
+}
+
+// LineXYPlotOf adds XY plot line of values of any numeric type.
+type LineXYPlotOf[T PlotNumber] struct {
 	title  string
-	xs, ys []float64
+	xs, ys []T
 	offset int
 	yAxis  ImPlotYAxis
+	buffer *PlotRingBuffer
+
+	plotHoverSeries[*LineXYPlotOf[T]]
 }
 
-// LineXY adds XY plot line to canvas.
-func LineXY(title string, xvalues, yvalues []float64) *LineXYPlot {
-	return &LineXYPlot{
+// LineXYPlot adds XY plot line.
+type LineXYPlot = LineXYPlotOf[float64]
+
+// LineXYOf adds XY plot line of values of any numeric type to canvas.
+// Values are passed to implot without conversion.
+func LineXYOf[T PlotNumber](title string, xvalues, yvalues []T) *LineXYPlotOf[T] {
+	p := &LineXYPlotOf[T]{
 		title:  title,
 		xs:     xvalues,
 		ys:     yvalues,
 		offset: 0,
 	}
+	p.self = p
+
+	return p
+}
+
+// LineXY adds XY plot line to canvas.
+func LineXY(title string, xvalues, yvalues []float64) *LineXYPlot {
+	return LineXYOf(title, xvalues, yvalues)
 }
 
 // SetPlotYAxis sets yAxis parameters.
-func (p *LineXYPlot) SetPlotYAxis(yAxis ImPlotYAxis) *LineXYPlot {
+func (p *LineXYPlotOf[T]) SetPlotYAxis(yAxis ImPlotYAxis) *LineXYPlotOf[T] {
 	p.yAxis = yAxis
 	return p
 }
 
 // Offset sets chart's offset.
-func (p *LineXYPlot) Offset(offset int) *LineXYPlot {
+func (p *LineXYPlotOf[T]) Offset(offset int) *LineXYPlotOf[T] {
 	p.offset = offset
 	return p
 }
 
 // Plot implements Plot interface.
-func (p *LineXYPlot) Plot() {
-	implot.SetAxis(implot.AxisEnum(p.yAxis))
-	implot.PlotLinedoublePtrdoublePtrV(
-		Context.PrepareString(p.title),
-		utils.SliceToPtr(p.xs),
-		utils.SliceToPtr(p.ys),
-		int32(len(p.xs)),
-		0, // flags
-		int32(p.offset),
-		8, // in fact this is sizeof(double) = 8
-	)
+func (p *LineXYPlotOf[T]) Plot() {
+	defer p.style.setNext(p.title)()
+
+	setPlotYAxis(p.yAxis)
+
+	if p.buffer != nil {
+		p.plotBuffer()
+		return
+	}
+
+	plotLineXY(Context.PrepareString(p.title), p.xs, p.ys, 0, p.offset)
+	plotHoverXY(p.onHover, p.xs, p.ys, p.offset)
+}
+
+// plotBuffer plots points of the ring buffer.
+func (p *LineXYPlotOf[T]) plotBuffer() {
+	b := p.buffer
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if xs, ys, ok := b.decimate(nil); ok {
+		plotLineXY(Context.PrepareString(p.title), xs, ys, 0, 0)
+	} else {
+		plotLineXY(Context.PrepareString(p.title), b.xs, b.ys, 0, b.start)
+	}
+
+	plotHoverXY(p.onHover, b.xs, b.ys, b.start)
 }
 
 // PieChartPlot represents a pie chart.
@@ -606,6 +826,8 @@ func (p *PieChartPlot) Angle0(a float64) *PieChartPlot {
 
 // Plot implements Plot interface.
 func (p *PieChartPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
 	var flags implot.PieChartFlags
 	if p.normalize {
 		flags |= implot.PieChartFlagsNormalize
@@ -624,89 +846,1032 @@ func (p *PieChartPlot) Plot() {
 	)
 }
 
-// ScatterPlot represents a scatter plot.
-type ScatterPlot struct {
+// ScatterPlotOf represents a scatter plot of values of any numeric type.
+type ScatterPlotOf[T PlotNumber] struct {
 	label      string
-	values     []float64
+	values     []T
 	xscale, x0 float64
 	offset     int
+
+	plotHoverSeries[*ScatterPlotOf[T]]
 }
 
-// Scatter adds scatter plot to the canvas.
-func Scatter(label string, values []float64) *ScatterPlot {
-	return &ScatterPlot{
+// ScatterPlot represents a scatter plot.
+type ScatterPlot = ScatterPlotOf[float64]
+
+// ScatterOf adds scatter plot of values of any numeric type to the canvas.
+// Values are passed to implot without conversion.
+func ScatterOf[T PlotNumber](label string, values []T) *ScatterPlotOf[T] {
+	p := &ScatterPlotOf[T]{
 		label:  label,
 		values: values,
 		xscale: 1,
 		x0:     0,
 		offset: 0,
 	}
+	p.self = p
+
+	return p
+}
+
+// Scatter adds scatter plot to the canvas.
+func Scatter(label string, values []float64) *ScatterPlot {
+	return ScatterOf(label, values)
 }
 
 // XScale sets x-axis scale.
-func (p *ScatterPlot) XScale(s float64) *ScatterPlot {
+func (p *ScatterPlotOf[T]) XScale(s float64) *ScatterPlotOf[T] {
 	p.xscale = s
 	return p
 }
 
 // X0 sets start position on x axis.
-func (p *ScatterPlot) X0(x float64) *ScatterPlot {
+func (p *ScatterPlotOf[T]) X0(x float64) *ScatterPlotOf[T] {
 	p.x0 = x
 	return p
 }
 
 // Offset sets chart offset.
-func (p *ScatterPlot) Offset(offset int) *ScatterPlot {
+func (p *ScatterPlotOf[T]) Offset(offset int) *ScatterPlotOf[T] {
 	p.offset = offset
 	return p
 }
 
 // Plot implements Plot interface.
-func (p *ScatterPlot) Plot() {
-	implot.PlotScatterdoublePtrIntV(
+func (p *ScatterPlotOf[T]) Plot() {
+	defer p.style.setNext(p.label)()
+
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	plotScatterValues(
 		Context.PrepareString(p.label),
-		utils.SliceToPtr(p.values),
-		int32(len(p.values)),
+		p.values,
 		p.xscale,
 		p.x0,
 		0, // TODO: implement flags
-		int32(p.offset),
-		8, // in fact this is sizeof(double) = 8
+		p.offset,
+		plotStride[T](),
 	)
+
+	plotHover(p.onHover, len(p.values), func(i int) float64 {
+		return p.x0 + float64(i)*p.xscale
+	}, func(i int) float64 {
+		return float64(p.values[(p.offset+i)%len(p.values)])
+	})
 }
 
-// ScatterXYPlot represents a scatter plot with possibility to set x and y values.
-type ScatterXYPlot struct {
+// ScatterXYPlotOf represents a scatter plot of values of any numeric type
+// with possibility to set x and y values.
+type ScatterXYPlotOf[T PlotNumber] struct {
 	label  string
-	xs, ys []float64
+	xs, ys []T
 	offset int
+
+	plotHoverSeries[*ScatterXYPlotOf[T]]
 }
 
-// ScatterXY adds scatter plot with x and y values.
-func ScatterXY(label string, xs, ys []float64) *ScatterXYPlot {
-	return &ScatterXYPlot{
+// ScatterXYPlot represents a scatter plot with possibility to set x and y values.
+type ScatterXYPlot = ScatterXYPlotOf[float64]
+
+// ScatterXYOf adds scatter plot of values of any numeric type with x and y values.
+// Values are passed to implot without conversion.
+func ScatterXYOf[T PlotNumber](label string, xs, ys []T) *ScatterXYPlotOf[T] {
+	p := &ScatterXYPlotOf[T]{
 		label:  label,
 		xs:     xs,
 		ys:     ys,
 		offset: 0,
 	}
+	p.self = p
+
+	return p
+}
+
+// ScatterXY adds scatter plot with x and y values.
+func ScatterXY(label string, xs, ys []float64) *ScatterXYPlot {
+	return ScatterXYOf(label, xs, ys)
 }
 
 // Offset sets chart offset.
-func (p *ScatterXYPlot) Offset(offset int) *ScatterXYPlot {
+func (p *ScatterXYPlotOf[T]) Offset(offset int) *ScatterXYPlotOf[T] {
 	p.offset = offset
 	return p
 }
 
 // Plot implements Plot interface.
-func (p *ScatterXYPlot) Plot() {
-	implot.PlotScatterdoublePtrdoublePtrV(
+func (p *ScatterXYPlotOf[T]) Plot() {
+	defer p.style.setNext(p.label)()
+
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	plotScatterXY(
 		Context.PrepareString(p.label),
-		utils.SliceToPtr(p.xs),
-		utils.SliceToPtr(p.ys),
-		int32(len(p.xs)),
+		p.xs,
+		p.ys,
 		0, // TODO: implement
+		p.offset,
+	)
+
+	plotHoverXY(p.onHover, p.xs, p.ys, p.offset)
+}
+
+// HistogramPlot represents a histogram of values.
+type HistogramPlot struct {
+	title              string
+	values             []float64
+	bins               PlotBins
+	barScale           float64
+	rangeMin, rangeMax float64
+	horizontal         bool
+	cumulative         bool
+	density            bool
+	noOutliers         bool
+	yAxis              ImPlotYAxis
+
+	plotSeries[*HistogramPlot]
+}
+
+// Histogram adds a histogram of values to the canvas.
+func Histogram(title string, values []float64) *HistogramPlot {
+	p := &HistogramPlot{
+		title:    title,
+		values:   values,
+		bins:     PlotBinsSturges,
+		barScale: 1,
+	}
+	p.self = p
+
+	return p
+}
+
+// Bins sets number of bins or a method of computing it.
+func (p *HistogramPlot) Bins(bins PlotBins) *HistogramPlot {
+	p.bins = bins
+	return p
+}
+
+// BarScale scales bars' heights.
+func (p *HistogramPlot) BarScale(scale float64) *HistogramPlot {
+	p.barScale = scale
+	return p
+}
+
+// Range sets range of values to be binned.
+// By default (if min == max) minimum and maximum of values are used.
+func (p *HistogramPlot) Range(rangeMin, rangeMax float64) *HistogramPlot {
+	p.rangeMin, p.rangeMax = rangeMin, rangeMax
+	return p
+}
+
+// Horizontal makes bars horizontal.
+func (p *HistogramPlot) Horizontal(h bool) *HistogramPlot {
+	p.horizontal = h
+	return p
+}
+
+// Cumulative makes each bin contain its count plus the counts of all previous bins.
+func (p *HistogramPlot) Cumulative(c bool) *HistogramPlot {
+	p.cumulative = c
+	return p
+}
+
+// Density normalizes counts to a probability density function.
+func (p *HistogramPlot) Density(d bool) *HistogramPlot {
+	p.density = d
+	return p
+}
+
+// NoOutliers excludes values outside of Range from normalization and cumulative counts.
+func (p *HistogramPlot) NoOutliers(n bool) *HistogramPlot {
+	p.noOutliers = n
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *HistogramPlot) SetPlotYAxis(yAxis ImPlotYAxis) *HistogramPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *HistogramPlot) Plot() {
+	defer p.style.setNext(p.title)()
+
+	var flags implot.HistogramFlags
+
+	if p.horizontal {
+		flags |= implot.HistogramFlagsHorizontal
+	}
+
+	if p.cumulative {
+		flags |= implot.HistogramFlagsCumulative
+	}
+
+	if p.density {
+		flags |= implot.HistogramFlagsDensity
+	}
+
+	if p.noOutliers {
+		flags |= implot.HistogramFlagsNoOutliers
+	}
+
+	valuesRange := implot.NewRangedouble(p.rangeMin, p.rangeMax)
+	defer valuesRange.Destroy()
+
+	setPlotYAxis(p.yAxis)
+
+	implot.PlotHistogramdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.values),
+		int32(len(p.values)),
+		int32(p.bins),
+		p.barScale,
+		*valuesRange,
+		flags,
+	)
+}
+
+// Histogram2DPlot represents a bivariate histogram drawn as a heatmap.
+type Histogram2DPlot struct {
+	title                  string
+	xs, ys                 []float64
+	xBins, yBins           PlotBins
+	xMin, xMax, yMin, yMax float64
+	density                bool
+	noOutliers             bool
+	colormap               PlotColormap
+}
+
+// Histogram2D adds a bivariate histogram of (xs[i], ys[i]) points to the canvas.
+func Histogram2D(title string, xs, ys []float64) *Histogram2DPlot {
+	return &Histogram2DPlot{
+		title:    title,
+		xs:       xs,
+		ys:       ys,
+		xBins:    PlotBinsSturges,
+		yBins:    PlotBinsSturges,
+		colormap: PlotColormapDefault,
+	}
+}
+
+// Bins sets number of bins (or a method of computing it) in both directions.
+func (p *Histogram2DPlot) Bins(xBins, yBins PlotBins) *Histogram2DPlot {
+	p.xBins, p.yBins = xBins, yBins
+	return p
+}
+
+// Range sets area of values to be binned.
+// By default (if min == max) minimum and maximum of values are used.
+func (p *Histogram2DPlot) Range(xMin, xMax, yMin, yMax float64) *Histogram2DPlot {
+	p.xMin, p.xMax, p.yMin, p.yMax = xMin, xMax, yMin, yMax
+	return p
+}
+
+// Density normalizes counts to a probability density function.
+func (p *Histogram2DPlot) Density(d bool) *Histogram2DPlot {
+	p.density = d
+	return p
+}
+
+// NoOutliers excludes values outside of Range from normalization.
+func (p *Histogram2DPlot) NoOutliers(n bool) *Histogram2DPlot {
+	p.noOutliers = n
+	return p
+}
+
+// Colormap sets colormap used to fill bins.
+func (p *Histogram2DPlot) Colormap(colormap PlotColormap) *Histogram2DPlot {
+	p.colormap = colormap
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *Histogram2DPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	var flags implot.HistogramFlags
+
+	if p.density {
+		flags |= implot.HistogramFlagsDensity
+	}
+
+	if p.noOutliers {
+		flags |= implot.HistogramFlagsNoOutliers
+	}
+
+	valuesRange := implot.NewRectdouble(p.xMin, p.xMax, p.yMin, p.yMax)
+	defer valuesRange.Destroy()
+
+	if p.colormap.push() {
+		defer implot.PopColormap()
+	}
+
+	implot.PlotHistogram2DdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.xs),
+		utils.SliceToPtr(p.ys),
+		int32(min(len(p.xs), len(p.ys))),
+		int32(p.xBins),
+		int32(p.yBins),
+		*valuesRange,
+		flags,
+	)
+}
+
+// HeatmapPlot represents a heatmap of a 2D matrix.
+type HeatmapPlot struct {
+	title                  string
+	values                 []float64
+	rows, cols             int
+	scaleMin, scaleMax     float64
+	labelFormat            string
+	boundsMinX, boundsMinY float64
+	boundsMaxX, boundsMaxY float64
+	colMajor               bool
+	colormap               PlotColormap
+}
+
+// Heatmap adds a heatmap to the canvas.
+// values is a rows x cols matrix stored in row-major order. The heatmap isn't plotted
+// if values are shorter.
+func Heatmap(title string, values []float64, rows, cols int) *HeatmapPlot {
+	return &HeatmapPlot{
+		title:       title,
+		values:      values,
+		rows:        rows,
+		cols:        cols,
+		labelFormat: "%.1f",
+		boundsMaxX:  1,
+		boundsMaxY:  1,
+		colormap:    PlotColormapDefault,
+	}
+}
+
+// Scale sets values mapped to the ends of the colormap.
+// By default (if min == max) minimum and maximum of values are used.
+func (p *HeatmapPlot) Scale(scaleMin, scaleMax float64) *HeatmapPlot {
+	p.scaleMin, p.scaleMax = scaleMin, scaleMax
+	return p
+}
+
+// LabelFormat sets format of the values printed in cells.
+// Empty string disables labels.
+func (p *HeatmapPlot) LabelFormat(fmtStr string) *HeatmapPlot {
+	p.labelFormat = fmtStr
+	return p
+}
+
+// Bounds sets area of the plot occupied by the heatmap (default is (0, 0) - (1, 1)).
+func (p *HeatmapPlot) Bounds(xMin, yMin, xMax, yMax float64) *HeatmapPlot {
+	p.boundsMinX, p.boundsMinY, p.boundsMaxX, p.boundsMaxY = xMin, yMin, xMax, yMax
+	return p
+}
+
+// ColMajor tells that values are stored in column-major order.
+func (p *HeatmapPlot) ColMajor(c bool) *HeatmapPlot {
+	p.colMajor = c
+	return p
+}
+
+// Colormap sets colormap used to fill cells.
+// Use the same colormap in ColormapScale to show a legend.
+func (p *HeatmapPlot) Colormap(colormap PlotColormap) *HeatmapPlot {
+	p.colormap = colormap
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *HeatmapPlot) Plot() {
+	// implot reads rows*cols values
+	if !heatmapValid(p.values, p.rows, p.cols) {
+		return
+	}
+
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	var flags implot.HeatmapFlags
+	if p.colMajor {
+		flags |= implot.HeatmapFlagsColMajor
+	}
+
+	if p.colormap.push() {
+		defer implot.PopColormap()
+	}
+
+	implot.PlotHeatmapdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.values),
+		int32(p.rows),
+		int32(p.cols),
+		p.scaleMin,
+		p.scaleMax,
+		p.labelFormat,
+		implot.NewPlotPoint(p.boundsMinX, p.boundsMinY),
+		implot.NewPlotPoint(p.boundsMaxX, p.boundsMaxY),
+		flags,
+	)
+}
+
+// heatmapValid returns true if values contain a rows x cols matrix.
+func heatmapValid(values []float64, rows, cols int) bool {
+	return rows > 0 && cols > 0 && rows*cols <= len(values)
+}
+
+var _ Widget = &ColormapScaleWidget{}
+
+// ColormapScaleWidget draws a vertical scale of a colormap.
+// It is a regular widget (not a PlotWidget) intended to be placed next to a Heatmap.
+type ColormapScaleWidget struct {
+	label              string
+	scaleMin, scaleMax float64
+	width, height      float32
+	format             string
+	colormap           PlotColormap
+}
+
+// ColormapScale creates a new ColormapScaleWidget.
+func ColormapScale(label string, scaleMin, scaleMax float64) *ColormapScaleWidget {
+	return &ColormapScaleWidget{
+		label:    label,
+		scaleMin: scaleMin,
+		scaleMax: scaleMax,
+		format:   "%g",
+		colormap: PlotColormapDefault,
+	}
+}
+
+// Size sets size of the scale (0 means default).
+func (c *ColormapScaleWidget) Size(width, height float32) *ColormapScaleWidget {
+	c.width, c.height = width, height
+	return c
+}
+
+// Format sets format of tick labels.
+func (c *ColormapScaleWidget) Format(format string) *ColormapScaleWidget {
+	c.format = format
+	return c
+}
+
+// Colormap sets colormap to show.
+func (c *ColormapScaleWidget) Colormap(colormap PlotColormap) *ColormapScaleWidget {
+	c.colormap = colormap
+	return c
+}
+
+// Build implements Widget interface.
+func (c *ColormapScaleWidget) Build() {
+	implot.ColormapScaleV(
+		Context.PrepareString(c.label),
+		c.scaleMin,
+		c.scaleMax,
+		imgui.Vec2{X: c.width, Y: c.height},
+		c.format,
+		0,
+		implot.Colormap(c.colormap),
+	)
+}
+
+// ShadedPlot fills area between two lines or between a line and a reference value.
+type ShadedPlot struct {
+	title       string
+	xs, ys, ys2 []float64
+	yRef        float64
+	offset      int
+	yAxis       ImPlotYAxis
+
+	plotSeries[*ShadedPlot]
+}
+
+// Shaded adds area between (xs, ys1) and (xs, ys2) lines to the canvas.
+func Shaded(title string, xs, ys1, ys2 []float64) *ShadedPlot {
+	p := &ShadedPlot{
+		title: title,
+		xs:    xs,
+		ys:    ys1,
+		ys2:   ys2,
+	}
+	p.self = p
+
+	return p
+}
+
+// ShadedRef adds area between (xs, ys) line and a horizontal line y = YRef (0 by default).
+func ShadedRef(title string, xs, ys []float64) *ShadedPlot {
+	p := &ShadedPlot{
+		title: title,
+		xs:    xs,
+		ys:    ys,
+	}
+	p.self = p
+
+	return p
+}
+
+// YRef sets reference value for ShadedRef. Use math.Inf for filling to the edge of the plot.
+func (p *ShadedPlot) YRef(yRef float64) *ShadedPlot {
+	p.yRef = yRef
+	return p
+}
+
+// Offset sets chart's offset.
+func (p *ShadedPlot) Offset(offset int) *ShadedPlot {
+	p.offset = offset
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *ShadedPlot) SetPlotYAxis(yAxis ImPlotYAxis) *ShadedPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *ShadedPlot) Plot() {
+	defer p.style.setNext(p.title)()
+
+	setPlotYAxis(p.yAxis)
+
+	if p.ys2 == nil {
+		implot.PlotShadeddoublePtrdoublePtrIntV(
+			Context.PrepareString(p.title),
+			utils.SliceToPtr(p.xs),
+			utils.SliceToPtr(p.ys),
+			int32(min(len(p.xs), len(p.ys))),
+			p.yRef,
+			0, // flags
+			int32(p.offset),
+			8, // in fact this is sizeof(double) = 8
+		)
+
+		return
+	}
+
+	implot.PlotShadeddoublePtrdoublePtrdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.xs),
+		utils.SliceToPtr(p.ys),
+		utils.SliceToPtr(p.ys2),
+		int32(min(len(p.xs), len(p.ys), len(p.ys2))),
+		0, // flags
+		int32(p.offset),
+		8, // in fact this is sizeof(double) = 8
+	)
+}
+
+// ErrorBarsPlot represents error bars.
+type ErrorBarsPlot struct {
+	title      string
+	xs, ys     []float64
+	neg, pos   []float64
+	horizontal bool
+	offset     int
+	yAxis      ImPlotYAxis
+}
+
+// ErrorBars adds symmetric error bars (ys[i] ± err[i]) to the canvas.
+func ErrorBars(title string, xs, ys, err []float64) *ErrorBarsPlot {
+	return ErrorBarsAsymmetric(title, xs, ys, err, err)
+}
+
+// ErrorBarsAsymmetric adds error bars from ys[i] - neg[i] to ys[i] + pos[i] to the canvas.
+func ErrorBarsAsymmetric(title string, xs, ys, neg, pos []float64) *ErrorBarsPlot {
+	return &ErrorBarsPlot{
+		title: title,
+		xs:    xs,
+		ys:    ys,
+		neg:   neg,
+		pos:   pos,
+	}
+}
+
+// Horizontal makes error bars horizontal (errors apply to xs).
+func (p *ErrorBarsPlot) Horizontal(h bool) *ErrorBarsPlot {
+	p.horizontal = h
+	return p
+}
+
+// Offset sets chart's offset.
+func (p *ErrorBarsPlot) Offset(offset int) *ErrorBarsPlot {
+	p.offset = offset
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *ErrorBarsPlot) SetPlotYAxis(yAxis ImPlotYAxis) *ErrorBarsPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *ErrorBarsPlot) Plot() {
+	var flags implot.ErrorBarsFlags
+	if p.horizontal {
+		flags |= implot.ErrorBarsFlagsHorizontal
+	}
+
+	setPlotYAxis(p.yAxis)
+
+	implot.PlotErrorBarsdoublePtrdoublePtrdoublePtrdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.xs),
+		utils.SliceToPtr(p.ys),
+		utils.SliceToPtr(p.neg),
+		utils.SliceToPtr(p.pos),
+		int32(min(len(p.xs), len(p.ys), len(p.neg), len(p.pos))),
+		flags,
 		int32(p.offset),
 		8, // in fact this is sizeof(double) = 8
 	)
 }
+
+// StairsPlot represents a stairstep graph.
+type StairsPlot struct {
+	title      string
+	xs, ys     []float64
+	xScale, x0 float64
+	preStep    bool
+	shaded     bool
+	offset     int
+	yAxis      ImPlotYAxis
+
+	plotSeries[*StairsPlot]
+}
+
+// Stairs adds a stairstep graph of values to the canvas.
+// Values are placed at x = X0 + i * XScale.
+func Stairs(title string, values []float64) *StairsPlot {
+	p := &StairsPlot{
+		title:  title,
+		ys:     values,
+		xScale: 1,
+	}
+	p.self = p
+
+	return p
+}
+
+// StairsXY adds a stairstep graph of (xs[i], ys[i]) points to the canvas.
+func StairsXY(title string, xs, ys []float64) *StairsPlot {
+	p := &StairsPlot{
+		title:  title,
+		xs:     xs,
+		ys:     ys,
+		xScale: 1,
+	}
+	p.self = p
+
+	return p
+}
+
+// XScale sets x-axis-scale (ignored for StairsXY).
+func (p *StairsPlot) XScale(scale float64) *StairsPlot {
+	p.xScale = scale
+	return p
+}
+
+// X0 sets a start position on x axis (ignored for StairsXY).
+func (p *StairsPlot) X0(x0 float64) *StairsPlot {
+	p.x0 = x0
+	return p
+}
+
+// PreStep makes y value continue to the left of each point (step happens before the point).
+func (p *StairsPlot) PreStep(preStep bool) *StairsPlot {
+	p.preStep = preStep
+	return p
+}
+
+// Shaded fills area between the stairs and the x axis.
+func (p *StairsPlot) Shaded(shaded bool) *StairsPlot {
+	p.shaded = shaded
+	return p
+}
+
+// Offset sets chart's offset.
+func (p *StairsPlot) Offset(offset int) *StairsPlot {
+	p.offset = offset
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *StairsPlot) SetPlotYAxis(yAxis ImPlotYAxis) *StairsPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *StairsPlot) Plot() {
+	defer p.style.setNext(p.title)()
+
+	var flags implot.StairsFlags
+
+	if p.preStep {
+		flags |= implot.StairsFlagsPreStep
+	}
+
+	if p.shaded {
+		flags |= implot.StairsFlagsShaded
+	}
+
+	setPlotYAxis(p.yAxis)
+
+	if p.xs == nil {
+		implot.PlotStairsdoublePtrIntV(
+			Context.PrepareString(p.title),
+			utils.SliceToPtr(p.ys),
+			int32(len(p.ys)),
+			p.xScale,
+			p.x0,
+			flags,
+			int32(p.offset),
+			8, // in fact this is sizeof(double) = 8
+		)
+
+		return
+	}
+
+	implot.PlotStairsdoublePtrdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.xs),
+		utils.SliceToPtr(p.ys),
+		int32(min(len(p.xs), len(p.ys))),
+		flags,
+		int32(p.offset),
+		8, // in fact this is sizeof(double) = 8
+	)
+}
+
+// StemsPlot represents a stem graph (vertical lines from a reference value with a marker at the top).
+type StemsPlot struct {
+	title      string
+	xs, ys     []float64
+	xScale, x0 float64
+	ref        float64
+	horizontal bool
+	offset     int
+	yAxis      ImPlotYAxis
+
+	plotSeries[*StemsPlot]
+}
+
+// Stems adds a stem graph of values to the canvas.
+// Values are placed at x = X0 + i * XScale.
+func Stems(title string, values []float64) *StemsPlot {
+	p := &StemsPlot{
+		title:  title,
+		ys:     values,
+		xScale: 1,
+	}
+	p.self = p
+
+	return p
+}
+
+// StemsXY adds a stem graph of (xs[i], ys[i]) points to the canvas.
+func StemsXY(title string, xs, ys []float64) *StemsPlot {
+	p := &StemsPlot{
+		title:  title,
+		xs:     xs,
+		ys:     ys,
+		xScale: 1,
+	}
+	p.self = p
+
+	return p
+}
+
+// XScale sets x-axis-scale (ignored for StemsXY).
+func (p *StemsPlot) XScale(scale float64) *StemsPlot {
+	p.xScale = scale
+	return p
+}
+
+// X0 sets a start position on x axis (ignored for StemsXY).
+func (p *StemsPlot) X0(x0 float64) *StemsPlot {
+	p.x0 = x0
+	return p
+}
+
+// Ref sets value the stems start at (0 by default).
+func (p *StemsPlot) Ref(ref float64) *StemsPlot {
+	p.ref = ref
+	return p
+}
+
+// Horizontal makes stems horizontal.
+func (p *StemsPlot) Horizontal(h bool) *StemsPlot {
+	p.horizontal = h
+	return p
+}
+
+// Offset sets chart's offset.
+func (p *StemsPlot) Offset(offset int) *StemsPlot {
+	p.offset = offset
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *StemsPlot) SetPlotYAxis(yAxis ImPlotYAxis) *StemsPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *StemsPlot) Plot() {
+	defer p.style.setNext(p.title)()
+
+	var flags implot.StemsFlags
+	if p.horizontal {
+		flags |= implot.StemsFlagsHorizontal
+	}
+
+	setPlotYAxis(p.yAxis)
+
+	if p.xs == nil {
+		implot.PlotStemsdoublePtrIntV(
+			Context.PrepareString(p.title),
+			utils.SliceToPtr(p.ys),
+			int32(len(p.ys)),
+			p.ref,
+			p.xScale,
+			p.x0,
+			flags,
+			int32(p.offset),
+			8, // in fact this is sizeof(double) = 8
+		)
+
+		return
+	}
+
+	implot.PlotStemsdoublePtrdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.xs),
+		utils.SliceToPtr(p.ys),
+		int32(min(len(p.xs), len(p.ys))),
+		p.ref,
+		flags,
+		int32(p.offset),
+		8, // in fact this is sizeof(double) = 8
+	)
+}
+
+// DigitalPlot represents a digital signal.
+// Digital plots are stacked at the bottom of the plot and are not affected by y axis scaling.
+type DigitalPlot struct {
+	title  string
+	xs, ys []float64
+	offset int
+
+	plotSeries[*DigitalPlot]
+}
+
+// Digital adds a digital signal of (xs[i], ys[i]) points to the canvas.
+// Non-zero y values are drawn as a high level.
+func Digital(title string, xs, ys []float64) *DigitalPlot {
+	p := &DigitalPlot{
+		title: title,
+		xs:    xs,
+		ys:    ys,
+	}
+	p.self = p
+
+	return p
+}
+
+// Offset sets chart's offset.
+func (p *DigitalPlot) Offset(offset int) *DigitalPlot {
+	p.offset = offset
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *DigitalPlot) Plot() {
+	defer p.style.setNext(p.title)()
+
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	implot.PlotDigitaldoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.xs),
+		utils.SliceToPtr(p.ys),
+		int32(min(len(p.xs), len(p.ys))),
+		0, // flags
+		int32(p.offset),
+		8, // in fact this is sizeof(double) = 8
+	)
+}
+
+// InfLinesPlot represents infinite lines crossing the plot at given positions.
+type InfLinesPlot struct {
+	title      string
+	values     []float64
+	horizontal bool
+	offset     int
+	yAxis      ImPlotYAxis
+
+	plotSeries[*InfLinesPlot]
+}
+
+// InfLines adds vertical lines at x = values[i] to the canvas.
+func InfLines(title string, values []float64) *InfLinesPlot {
+	p := &InfLinesPlot{
+		title:  title,
+		values: values,
+	}
+	p.self = p
+
+	return p
+}
+
+// Horizontal makes lines horizontal (at y = values[i]).
+func (p *InfLinesPlot) Horizontal(h bool) *InfLinesPlot {
+	p.horizontal = h
+	return p
+}
+
+// Offset sets chart's offset.
+func (p *InfLinesPlot) Offset(offset int) *InfLinesPlot {
+	p.offset = offset
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *InfLinesPlot) SetPlotYAxis(yAxis ImPlotYAxis) *InfLinesPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *InfLinesPlot) Plot() {
+	defer p.style.setNext(p.title)()
+
+	var flags implot.InfLinesFlags
+	if p.horizontal {
+		flags |= implot.InfLinesFlagsHorizontal
+	}
+
+	setPlotYAxis(p.yAxis)
+
+	implot.PlotInfLinesdoublePtrV(
+		Context.PrepareString(p.title),
+		utils.SliceToPtr(p.values),
+		int32(len(p.values)),
+		flags,
+		int32(p.offset),
+		8, // in fact this is sizeof(double) = 8
+	)
+}
+
+// TextPlot represents a text annotation placed at a point of the plot.
+type TextPlot struct {
+	text             string
+	x, y             float64
+	offsetX, offsetY float32
+	vertical         bool
+	yAxis            ImPlotYAxis
+}
+
+// PlotText adds text centered at (x, y) to the canvas.
+func PlotText(text string, x, y float64) *TextPlot {
+	return &TextPlot{
+		text: text,
+		x:    x,
+		y:    y,
+	}
+}
+
+// PixelOffset moves text by (x, y) pixels.
+func (p *TextPlot) PixelOffset(x, y float32) *TextPlot {
+	p.offsetX, p.offsetY = x, y
+	return p
+}
+
+// Vertical rotates text by 90 degrees.
+func (p *TextPlot) Vertical(v bool) *TextPlot {
+	p.vertical = v
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *TextPlot) SetPlotYAxis(yAxis ImPlotYAxis) *TextPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *TextPlot) Plot() {
+	var flags implot.TextFlags
+	if p.vertical {
+		flags |= implot.TextFlagsVertical
+	}
+
+	setPlotYAxis(p.yAxis)
+
+	implot.PlotTextV(
+		Context.PrepareString(p.text),
+		p.x,
+		p.y,
+		imgui.Vec2{X: p.offsetX, Y: p.offsetY},
+		flags,
+	)
+}
@@ -0,0 +1,322 @@
+package giu
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/implot"
+	"github.com/AllenDang/cimgui-go/utils"
+)
+
+// PlotFormatter formats value of an axis as a tick label.
+type PlotFormatter func(value float64) string
+
+// PlotTickGenerator returns ticks of an axis for its visible range [min, max].
+// Empty labels are formatted by the axis' formatter.
+type PlotTickGenerator func(min, max float64) []PlotTicker
+
+// plotAxis holds setup of a single plot axis.
+type plotAxis struct {
+	label            string
+	flags            PlotAxisFlags
+	scale            PlotScale
+	limits           *plotAxisLimits
+	ticksValue       []float64
+	ticksLabel       []string
+	ticksShowDefault bool
+	formatter        PlotFormatter
+	ticker           PlotTickGenerator
+}
+
+type plotAxisLimits struct {
+	min, max float64
+	cond     ExecCondition
+}
+
+// SetAxisLimits sets limits of any axis.
+func (p *PlotCanvasWidget) SetAxisLimits(axis PlotAxis, minimum, maximum float64, cond ExecCondition) *PlotCanvasWidget {
+	p.axes[axis].limits = &plotAxisLimits{min: minimum, max: maximum, cond: cond}
+	return p
+}
+
+// SetAxisTicks sets ticks of any axis.
+// If showDefault is true, the default ticks are shown as well.
+func (p *PlotCanvasWidget) SetAxisTicks(axis PlotAxis, ticks []PlotTicker, showDefault bool) *PlotCanvasWidget {
+	a := &p.axes[axis]
+	a.ticksValue, a.ticksLabel = make([]float64, len(ticks)), make([]string, len(ticks))
+
+	for i, t := range ticks {
+		a.ticksValue[i] = t.Position
+		a.ticksLabel[i] = t.Label
+	}
+
+	a.ticksShowDefault = showDefault
+
+	return p
+}
+
+// SetAxisFlags sets flags of any axis.
+func (p *PlotCanvasWidget) SetAxisFlags(axis PlotAxis, flags PlotAxisFlags) *PlotCanvasWidget {
+	p.axes[axis].flags = flags
+	return p
+}
+
+// SetAxisScale sets scale of any axis.
+func (p *PlotCanvasWidget) SetAxisScale(axis PlotAxis, scale PlotScale) *PlotCanvasWidget {
+	p.axes[axis].scale = scale
+	return p
+}
+
+// SetAxisFormatter sets function formatting tick labels of the axis.
+// Ticks are placed at "nice" values of the visible range (unless set by SetAxisTicker or SetAxisTicks).
+func (p *PlotCanvasWidget) SetAxisFormatter(axis PlotAxis, formatter PlotFormatter) *PlotCanvasWidget {
+	p.axes[axis].formatter = formatter
+	return p
+}
+
+// SetAxisTicker sets function generating ticks of the axis.
+// Unlike SetAxisTicks, it is called every frame with the visible range, so the ticks follow zooming and panning.
+func (p *PlotCanvasWidget) SetAxisTicker(axis PlotAxis, ticker PlotTickGenerator) *PlotCanvasWidget {
+	p.axes[axis].ticker = ticker
+	return p
+}
+
+// axisEnabled returns true if the axis should be shown.
+// X1 and Y1 are always enabled, others when any of their properties is set.
+func (p *PlotCanvasWidget) axisEnabled(axis PlotAxis) bool {
+	a := &p.axes[axis]
+
+	return axis == AxisX1 || axis == AxisY1 ||
+		a.label != "" || a.limits != nil || len(a.ticksValue) > 0 ||
+		a.formatter != nil || a.ticker != nil || p.axisLinks[axis] != nil
+}
+
+// setupAxis sets up the axis of the current plot. It must be called before any setup locking function.
+func (p *PlotCanvasWidget) setupAxis(axis PlotAxis) {
+	if !p.axisEnabled(axis) {
+		return
+	}
+
+	a := &p.axes[axis]
+
+	implot.SetupAxisV(axis, Context.PrepareString(a.label), implot.AxisFlags(a.flags))
+	implot.SetupAxisScalePlotScale(axis, implot.Scale(a.scale))
+
+	if a.limits != nil {
+		implot.SetupAxisLimitsV(axis, a.limits.min, a.limits.max, implot.Cond(a.limits.cond))
+	}
+
+	if r := p.axisLinks[axis]; r != nil {
+		implot.SetupAxisLinks(axis, &r.Min, &r.Max)
+	}
+
+	values, labels, showDefault := a.ticksValue, a.ticksLabel, a.ticksShowDefault
+
+	if a.ticker != nil || (a.formatter != nil && len(values) == 0) {
+		var ticks []PlotTicker
+
+		minimum, maximum, pixels := p.axisRange(axis)
+		if a.ticker != nil {
+			ticks = a.ticker(minimum, maximum)
+		} else {
+			for _, v := range plotTickValues(minimum, maximum, a.scale, pixels, axis >= AxisY1) {
+				ticks = append(ticks, PlotTicker{Position: v})
+			}
+		}
+
+		values, labels = make([]float64, len(ticks)), make([]string, len(ticks))
+		for i, t := range ticks {
+			values[i], labels[i] = t.Position, t.Label
+		}
+
+		showDefault = false
+	}
+
+	if len(values) == 0 {
+		return
+	}
+
+	if a.formatter != nil {
+		formatted := make([]string, len(labels))
+		for i, label := range labels {
+			if label == "" {
+				label = a.formatter(values[i])
+			}
+
+			formatted[i] = label
+		}
+
+		labels = formatted
+	}
+
+	implot.SetupAxisTicksdoublePtrV(axis, utils.SliceToPtr(values), int32(len(values)), labels, showDefault)
+}
+
+// axisRange returns visible range of the axis and its size in pixels.
+// It must be called after limits and links of the axis are set up, as implot applies them immediately.
+// The range is the one implot generates its own ticks for in SetupFinish
+// (cimgui-go's formatter callback can't write labels, so labeled ticks are set up instead).
+// The size in pixels (used only to choose count of ticks) is known after SetupFinish,
+// so the size of the last frame is used.
+func (p *PlotCanvasWidget) axisRange(axis PlotAxis) (minimum, maximum float64, pixels float32) {
+	plot := implot.GetCurrentPlot()
+
+	var a *implot.Axis
+	if axis < AxisY1 {
+		a = plot.XAxis(int32(axis))
+	} else {
+		a = plot.YAxis(int32(axis - AxisY1))
+	}
+
+	// SetupFinish constrains the range before locating ticks, do the same.
+	a.Constrain()
+
+	r := a.Range()
+
+	return r.Min(), r.Max(), a.PixelSize()
+}
+
+// plotTickValues returns positions of ticks for range [minimum, maximum] of an axis
+// that is pixels long (about one tick per 100 pixels or 50 pixels on vertical axes).
+// Logarithmic axes get ticks at powers of 10.
+func plotTickValues(minimum, maximum float64, scale PlotScale, pixels float32, vertical bool) []float64 {
+	if minimum > maximum {
+		minimum, maximum = maximum, minimum
+	}
+
+	if !(maximum > minimum) || math.IsInf(maximum-minimum, 0) {
+		return nil
+	}
+
+	spacing := float32(100)
+	if vertical {
+		spacing = 50
+	}
+
+	count := max(2, int(math.Round(float64(pixels/spacing))))
+
+	if scale == PlotScaleLog10 && minimum > 0 {
+		if ticks := logTickValues(minimum, maximum, count); len(ticks) >= 2 {
+			return ticks
+		}
+	}
+
+	interval := niceNum((maximum - minimum) / float64(count-1))
+	first := math.Ceil(minimum / interval)
+
+	var result []float64
+
+	for i := first; i*interval <= maximum; i++ {
+		v := i * interval
+		if v == 0 {
+			v = 0 // avoid negative zero
+		}
+
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// logTickValues returns powers of 10 of range [minimum, maximum] (at most about count of them).
+func logTickValues(minimum, maximum float64, count int) []float64 {
+	first, last := int(math.Ceil(math.Log10(minimum))), int(math.Floor(math.Log10(maximum)))
+	step := max(1, (last-first+count)/count)
+
+	var result []float64
+	for e := first; e <= last; e += step {
+		result = append(result, math.Pow10(e))
+	}
+
+	return result
+}
+
+// niceNum returns a "nice" number (1, 2 or 5 times a power of 10) nearest to x.
+func niceNum(x float64) float64 {
+	exp := math.Floor(math.Log10(x))
+	f := x / math.Pow(10, exp)
+
+	var nice float64
+
+	switch {
+	case f < 1.5:
+		nice = 1
+	case f < 3:
+		nice = 2
+	case f < 7:
+		nice = 5
+	default:
+		nice = 10
+	}
+
+	return nice * math.Pow(10, exp)
+}
+
+var siPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+// PlotFormatSI returns formatter printing values with SI prefix and unit (e.g. 1.5 kHz).
+// precision is the number of decimal places.
+func PlotFormatSI(unit string, precision int) PlotFormatter {
+	return func(value float64) string {
+		prefix := ""
+
+		if value != 0 && !math.IsInf(value, 0) && !math.IsNaN(value) {
+			exp := int(math.Floor(math.Log10(math.Abs(value)) / 3))
+			exp = min(max(exp, -8), 8)
+			value /= math.Pow(1000, float64(exp))
+			prefix = siPrefixes[exp+8]
+		}
+
+		return withUnit(strconv.FormatFloat(value, 'f', precision, 64), prefix+unit)
+	}
+}
+
+// PlotFormatPercent returns formatter printing fractions as percents (0.25 is 25%).
+// precision is the number of decimal places.
+func PlotFormatPercent(precision int) PlotFormatter {
+	return func(value float64) string {
+		return strconv.FormatFloat(value*100, 'f', precision, 64) + "%"
+	}
+}
+
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// PlotFormatBytes returns formatter printing numbers of bytes with binary units (e.g. 1.5 MiB).
+// precision is the number of decimal places (bytes are always printed as integers).
+func PlotFormatBytes(precision int) PlotFormatter {
+	return func(value float64) string {
+		unit, prec := 0, precision
+		for ; unit < len(byteUnits)-1 && math.Abs(value) >= 1024; unit++ {
+			value /= 1024
+		}
+
+		if unit == 0 {
+			prec = 0
+		}
+
+		return withUnit(strconv.FormatFloat(value, 'f', prec, 64), byteUnits[unit])
+	}
+}
+
+// PlotFormatDuration returns formatter printing seconds as durations (e.g. 1m30s).
+// Values are rounded to multiples of round (if positive).
+func PlotFormatDuration(round time.Duration) PlotFormatter {
+	return func(value float64) string {
+		d := time.Duration(math.Round(value * float64(time.Second)))
+		if round > 0 {
+			d = d.Round(round)
+		}
+
+		return d.String()
+	}
+}
+
+func withUnit(value, unit string) string {
+	if unit == "" {
+		return value
+	}
+
+	return fmt.Sprintf("%s %s", value, unit)
+}
@@ -0,0 +1,58 @@
+package giu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlotFormatters(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter PlotFormatter
+		value     float64
+		expected  string
+	}{
+		{"SI kilo", PlotFormatSI("Hz", 1), 1500, "1.5 kHz"},
+		{"SI milli", PlotFormatSI("s", 0), 0.002, "2 ms"},
+		{"SI no prefix", PlotFormatSI("V", 2), 3.14159, "3.14 V"},
+		{"SI zero", PlotFormatSI("V", 0), 0, "0 V"},
+		{"SI no unit", PlotFormatSI("", 0), -2e6, "-2 M"},
+		{"percent", PlotFormatPercent(1), 0.255, "25.5%"},
+		{"bytes", PlotFormatBytes(1), 512, "512 B"},
+		{"kibibytes", PlotFormatBytes(1), 1536, "1.5 KiB"},
+		{"gibibytes", PlotFormatBytes(0), 3 << 30, "3 GiB"},
+		{"duration", PlotFormatDuration(0), 90, "1m30s"},
+		{"rounded duration", PlotFormatDuration(time.Millisecond), 1.23456, "1.235s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.formatter(tt.value))
+		})
+	}
+}
+
+func Test_plotTickValues(t *testing.T) {
+	tests := []struct {
+		name             string
+		minimum, maximum float64
+		scale            PlotScale
+		pixels           float32
+		vertical         bool
+		expected         []float64
+	}{
+		{"linear", 0, 10, PlotScaleLinear, 600, false, []float64{0, 2, 4, 6, 8, 10}},
+		{"vertical", -1, 1, PlotScaleLinear, 200, true, []float64{-1, -0.5, 0, 0.5, 1}},
+		{"inverted", 10, 0, PlotScaleLinear, 600, false, []float64{0, 2, 4, 6, 8, 10}},
+		{"logarithmic", 1, 1e4, PlotScaleLog10, 200, false, []float64{1, 1000}},
+		{"empty range", 5, 5, PlotScaleLinear, 600, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, plotTickValues(tt.minimum, tt.maximum, tt.scale, tt.pixels, tt.vertical))
+		})
+	}
+}
@@ -0,0 +1,547 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// PlotExportFormat is a file format plots can be exported to.
+type PlotExportFormat string
+
+// Plot export formats.
+const (
+	// PlotExportCSV exports data of all the series as CSV (columns: series, x, y).
+	// If there are candlestick (or OHLC) series, y is the close price and open, low and high
+	// columns are added.
+	PlotExportCSV PlotExportFormat = "csv"
+	// PlotExportSVG renders axes, grid, series and legend as a vector image.
+	PlotExportSVG PlotExportFormat = "svg"
+	// PlotExportPNG is a capture of the plot as it is displayed (see CapturePNG).
+	PlotExportPNG PlotExportFormat = "png"
+)
+
+// Errors returned by exporting plots.
+var (
+	// ErrUnknownPlotExportFormat is returned when exporting a plot to unsupported format.
+	ErrUnknownPlotExportFormat = errors.New("unknown plot export format")
+	// ErrPlotSeriesNotExportable is returned when the plot contains a series which can't be exported
+	// (e.g. pie chart or heatmap).
+	ErrPlotSeriesNotExportable = errors.New("plot series can't be exported")
+)
+
+// plotSeriesKind tells how the series is drawn by exporters.
+type plotSeriesKind byte
+
+const (
+	plotSeriesLine plotSeriesKind = iota
+	plotSeriesScatter
+	plotSeriesBars
+	plotSeriesBarsH
+	plotSeriesStairs
+	plotSeriesStairsPre
+	plotSeriesStems
+	plotSeriesStemsH
+	plotSeriesCandles
+	plotSeriesOHLC
+)
+
+// plotSeriesData is data of a series prepared for exporting.
+type plotSeriesData struct {
+	label  string
+	kind   plotSeriesKind
+	xs, ys []float64
+	yAxis  ImPlotYAxis
+	// width of bars or candles, or reference value of stems.
+	size  float64
+	color color.Color
+
+	// open, low and high prices of candles (ys are close prices) and color of falling candles.
+	open, low, high []float64
+	bearColor       color.Color
+}
+
+// plotExporter is implemented by plots which data can be exported.
+type plotExporter interface {
+	exportData() plotSeriesData
+}
+
+// exportValues returns points of values placed at x = x0 + i * xScale.
+// As in implot, i-th point has value values[(offset + i) % len(values)].
+func exportValues[T PlotNumber](values []T, xScale, x0 float64, offset int) (xs, ys []float64) {
+	xs, ys = make([]float64, len(values)), make([]float64, len(values))
+	for i := range values {
+		xs[i] = x0 + float64(i)*xScale
+		ys[i] = float64(values[(offset+i)%len(values)])
+	}
+
+	return xs, ys
+}
+
+// exportXY returns points (xs[i], ys[i]) rotated by offset.
+func exportXY[T PlotNumber](xs, ys []T, offset int) (resultX, resultY []float64) {
+	n := min(len(xs), len(ys))
+
+	resultX, resultY = make([]float64, n), make([]float64, n)
+	for i := range n {
+		resultX[i] = float64(xs[(offset+i)%n])
+		resultY[i] = float64(ys[(offset+i)%n])
+	}
+
+	return resultX, resultY
+}
+
+// ExportMenu adds "Export" submenu to the plot's context menu (right click on the plot).
+// The menu is turned on by setting onExport (nil turns it off) instead of a flag, because the plot
+// doesn't save files itself: onExport receives the exported file (or the error of exporting)
+// and decides where to save it (e.g. asks the user with a save dialog).
+// PNG is captured from the frame rendered after the menu is closed (see CapturePNG).
+func (p *PlotCanvasWidget) ExportMenu(onExport func(format PlotExportFormat, data []byte, err error)) *PlotCanvasWidget {
+	p.onExport = onExport
+	return p
+}
+
+// Export writes the plot in PlotExportCSV or PlotExportSVG format to w
+// (PNG is captured from the rendered frame with CapturePNG).
+// SVG images are width x height pixels large. If a size is not positive,
+// the size of the plot from the last frame is used.
+// SVG images are drawn again from data of the series with default style
+// (line weights, markers, fills and the plot's theme are not kept).
+// Lines, scatters, bars, stairs, stems and candlesticks can be exported;
+// ErrPlotSeriesNotExportable is returned if the plot contains other series.
+// The plot must have been built at least once and Export must be called from the rendering goroutine
+// (e.g. in a widget's callback) as it uses plot's visible ranges and colors of the last frame.
+func (p *PlotCanvasWidget) Export(w io.Writer, format PlotExportFormat, width, height int) error {
+	switch format {
+	case PlotExportCSV:
+		return p.ExportCSV(w)
+	case PlotExportSVG:
+		return p.ExportSVG(w, width, height)
+	case PlotExportPNG:
+		return fmt.Errorf("%w: %q has to be captured with CapturePNG", ErrUnknownPlotExportFormat, format)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownPlotExportFormat, format)
+	}
+}
+
+// ExportCSV writes data of all the exportable series (lines, scatters, bars, stairs, stems and candlesticks)
+// as CSV with series, x and y columns (see PlotExportCSV).
+func (p *PlotCanvasWidget) ExportCSV(w io.Writer) error {
+	series, err := exportSeries(p.plots)
+	if err != nil {
+		return err
+	}
+
+	return writePlotCSV(w, series)
+}
+
+// writePlotCSV writes points of series as CSV.
+func writePlotCSV(w io.Writer, series []plotSeriesData) error {
+	candles := slices.ContainsFunc(series, func(s plotSeriesData) bool {
+		return s.open != nil
+	})
+
+	header := []string{"series", "x", "y"}
+	if candles {
+		header = append(header, "open", "low", "high")
+	}
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("giu: writing CSV: %w", err)
+	}
+
+	formatFloat := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	for _, s := range series {
+		for i := range s.xs {
+			record := []string{plotTitle(s.label), formatFloat(s.xs[i]), formatFloat(s.ys[i])}
+
+			switch {
+			case s.open != nil:
+				record = append(record, formatFloat(s.open[i]), formatFloat(s.low[i]), formatFloat(s.high[i]))
+			case candles:
+				record = append(record, "", "", "")
+			}
+
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("giu: writing CSV: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("giu: writing CSV: %w", err)
+	}
+
+	return nil
+}
+
+// ExportSVG renders the plot (axes, grid, visible series and legend) to SVG image.
+// See Export for details.
+func (p *PlotCanvasWidget) ExportSVG(w io.Writer, width, height int) error {
+	width, height = p.exportSize(width, height)
+
+	scene, err := p.exportScene()
+	if err != nil {
+		return err
+	}
+
+	painter := newSVGPainter(width, height)
+	scene.render(painter, width, height)
+
+	if _, err := w.Write(painter.bytes()); err != nil {
+		return fmt.Errorf("giu: writing SVG: %w", err)
+	}
+
+	return nil
+}
+
+// CapturePNG captures the plot as it is displayed (with the application's fonts and style)
+// when it is rendered next time and passes the PNG image to onCapture.
+// onCapture is called on the rendering goroutine after the frame is rendered
+// (with ErrFrameNotCaptured if the plot is not visible). Images drawn with user textures are not captured.
+func (p *PlotCanvasWidget) CapturePNG(onCapture func(data []byte, err error)) {
+	p.getState().capture = onCapture
+
+	Update()
+}
+
+// requestCapture requests capture of the plot's frame (the last item) if CapturePNG was called.
+func (p *PlotCanvasWidget) requestCapture(state *plotCanvasState) {
+	if state.capture == nil {
+		return
+	}
+
+	onCapture := state.capture
+	state.capture = nil
+
+	captureFrame(imgui.ItemRectMin(), imgui.ItemRectMax(), func(img *image.NRGBA, err error) {
+		if err != nil {
+			onCapture(nil, err)
+			return
+		}
+
+		buf := &bytes.Buffer{}
+		if err := png.Encode(buf, img); err != nil {
+			onCapture(nil, fmt.Errorf("giu: writing PNG: %w", err))
+			return
+		}
+
+		onCapture(buf.Bytes(), nil)
+	})
+}
+
+// exportSeries returns data of exportable series. Plot tools (drag lines, tags, annotations)
+// and custom widgets are skipped; for other series ErrPlotSeriesNotExportable is returned.
+func exportSeries(plots []PlotWidget) ([]plotSeriesData, error) {
+	var result []plotSeriesData
+
+	for _, plot := range plots {
+		switch plot := plot.(type) {
+		case plotExporter:
+			result = append(result, plot.exportData())
+		case *StyleSetter:
+			series, err := exportSeries(plot.plots)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, series...)
+		case *PieChartPlot, *HistogramPlot, *Histogram2DPlot, *HeatmapPlot, *ShadedPlot,
+			*ErrorBarsPlot, *DigitalPlot, *InfLinesPlot, *TextPlot:
+			return nil, fmt.Errorf("%w: %T", ErrPlotSeriesNotExportable, plot)
+		}
+	}
+
+	return result, nil
+}
+
+// exportSize returns the given size or the size of the plot in the last frame.
+func (p *PlotCanvasWidget) exportSize(width, height int) (w, h int) {
+	size := p.getState().frameSize
+
+	if width <= 0 {
+		width = size.X
+	}
+
+	if height <= 0 {
+		height = size.Y
+	}
+
+	if width <= 0 || height <= 0 {
+		width, height = 800, 600
+	}
+
+	return width, height
+}
+
+// recordFrame remembers visible ranges of the axes and size of the plot
+// (it must be called between BeginPlot and EndPlot).
+func (p *PlotCanvasWidget) recordFrame() {
+	state := p.getState()
+	plot := implot.GetCurrentPlot()
+
+	for i := range implot.AxisCOUNT {
+		var a *implot.Axis
+		if i < AxisY1 {
+			a = plot.XAxis(int32(i))
+		} else {
+			a = plot.YAxis(int32(i - AxisY1))
+		}
+
+		r := a.Range()
+		state.ranges[i] = PlotRange{Min: r.Min(), Max: r.Max()}
+	}
+
+	state.recorded = true
+}
+
+// buildExportMenu adds export items to implot's context menu of the plot.
+// implot opens the menu in EndPlot, so it must be called afterwards with the id of the plot.
+func (p *PlotCanvasWidget) buildExportMenu(plotID imgui.ID) {
+	imgui.InternalPushOverrideID(plotID)
+	defer imgui.PopID()
+
+	if !imgui.BeginPopup("##PlotContext") {
+		return
+	}
+
+	defer imgui.EndPopup()
+
+	imgui.Separator()
+
+	if !imgui.BeginMenu("Export") {
+		return
+	}
+
+	defer imgui.EndMenu()
+
+	for _, format := range []PlotExportFormat{PlotExportCSV, PlotExportSVG, PlotExportPNG} {
+		if imgui.MenuItemBool(strings.ToUpper(string(format))) {
+			p.exportFromMenu(format)
+		}
+	}
+}
+
+// exportFromMenu exports the plot in format and passes the result to the ExportMenu's callback.
+func (p *PlotCanvasWidget) exportFromMenu(format PlotExportFormat) {
+	onExport := p.onExport
+
+	if format == PlotExportPNG {
+		p.CapturePNG(func(data []byte, err error) {
+			onExport(format, data, err)
+		})
+
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := p.Export(buf, format, 0, 0); err != nil {
+		onExport(format, nil, err)
+		return
+	}
+
+	onExport(format, buf.Bytes(), nil)
+}
+
+// plotTitle returns title without imgui's ID suffix.
+func plotTitle(title string) string {
+	if i := strings.Index(title, "##"); i >= 0 {
+		return title[:i]
+	}
+
+	return title
+}
+
+// itemColor converts implot's packed color to color.NRGBA.
+func itemColor(c uint32) color.NRGBA {
+	return color.NRGBA{R: uint8(c), G: uint8(c >> 8), B: uint8(c >> 16), A: uint8(c >> 24)}
+}
+
+// plotFrameSize returns size of the last item (the plot's frame after EndPlot).
+func plotFrameSize() image.Point {
+	size := imgui.ItemRectSize()
+	return image.Pt(int(size.X), int(size.Y))
+}
+
+func (p *BarPlotOf[T]) exportData() plotSeriesData {
+	xs, ys := exportValues(p.data, 1, p.shift, p.offset)
+	if p.xs != nil {
+		xs, ys = exportXY(p.xs, p.data, p.offset)
+	}
+
+	return plotSeriesData{
+		label: Context.PrepareString(p.title),
+		kind:  plotSeriesBars,
+		xs:    xs,
+		ys:    ys,
+		yAxis: p.yAxis,
+		size:  p.width,
+		color: p.style.color,
+	}
+}
+
+func (p *BarHPlotOf[T]) exportData() plotSeriesData {
+	ys, xs := exportValues(p.data, 1, p.shift, p.offset)
+
+	return plotSeriesData{
+		label: Context.PrepareString(p.title),
+		kind:  plotSeriesBarsH,
+		xs:    xs,
+		ys:    ys,
+		size:  p.height,
+		color: p.style.color,
+	}
+}
+
+func (p *LinePlotOf[T]) exportData() plotSeriesData {
+	result := plotSeriesData{
+		label: Context.PrepareString(p.title),
+		kind:  plotSeriesLine,
+		yAxis: p.yAxis,
+		color: p.style.color,
+	}
+
+	if b := p.buffer; b != nil {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+
+		result.xs, result.ys = exportValues(b.ys, p.xScale, p.x0, b.start)
+	} else {
+		result.xs, result.ys = exportValues(p.values, p.xScale, p.x0, p.offset)
+	}
+
+	return result
+}
+
+func (p *LineXYPlotOf[T]) exportData() plotSeriesData {
+	result := plotSeriesData{
+		label: Context.PrepareString(p.title),
+		kind:  plotSeriesLine,
+		yAxis: p.yAxis,
+		color: p.style.color,
+	}
+
+	if b := p.buffer; b != nil {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+
+		result.xs, result.ys = exportXY(b.xs, b.ys, b.start)
+	} else {
+		result.xs, result.ys = exportXY(p.xs, p.ys, p.offset)
+	}
+
+	return result
+}
+
+func (p *ScatterPlotOf[T]) exportData() plotSeriesData {
+	xs, ys := exportValues(p.values, p.xscale, p.x0, p.offset)
+
+	return plotSeriesData{
+		label: Context.PrepareString(p.label),
+		kind:  plotSeriesScatter,
+		xs:    xs,
+		ys:    ys,
+		color: p.style.color,
+	}
+}
+
+func (p *ScatterXYPlotOf[T]) exportData() plotSeriesData {
+	xs, ys := exportXY(p.xs, p.ys, p.offset)
+
+	return plotSeriesData{
+		label: Context.PrepareString(p.label),
+		kind:  plotSeriesScatter,
+		xs:    xs,
+		ys:    ys,
+		color: p.style.color,
+	}
+}
+
+func (p *StairsPlot) exportData() plotSeriesData {
+	result := plotSeriesData{
+		label: Context.PrepareString(p.title),
+		kind:  plotSeriesStairs,
+		yAxis: p.yAxis,
+		color: p.style.color,
+	}
+
+	if p.preStep {
+		result.kind = plotSeriesStairsPre
+	}
+
+	if p.xs == nil {
+		result.xs, result.ys = exportValues(p.ys, p.xScale, p.x0, p.offset)
+	} else {
+		result.xs, result.ys = exportXY(p.xs, p.ys, p.offset)
+	}
+
+	return result
+}
+
+func (p *StemsPlot) exportData() plotSeriesData {
+	result := plotSeriesData{
+		label: Context.PrepareString(p.title),
+		kind:  plotSeriesStems,
+		yAxis: p.yAxis,
+		size:  p.ref,
+		color: p.style.color,
+	}
+
+	switch {
+	case p.xs != nil:
+		result.xs, result.ys = exportXY(p.xs, p.ys, p.offset)
+	case p.horizontal:
+		// values are placed on y axis
+		result.ys, result.xs = exportValues(p.ys, p.xScale, p.x0, p.offset)
+	default:
+		result.xs, result.ys = exportValues(p.ys, p.xScale, p.x0, p.offset)
+	}
+
+	if p.horizontal {
+		result.kind = plotSeriesStemsH
+	}
+
+	return result
+}
+
+func (p *CandlestickPlot) exportData() plotSeriesData {
+	n := p.count()
+
+	result := plotSeriesData{
+		label:     Context.PrepareString(p.title),
+		kind:      plotSeriesCandles,
+		xs:        slices.Clone(p.dates[:n]),
+		ys:        slices.Clone(p.closing[:n]),
+		yAxis:     p.yAxis,
+		size:      2 * candleHalfWidth(p.dates[:n], p.width),
+		color:     p.bullColor,
+		open:      slices.Clone(p.open[:n]),
+		low:       slices.Clone(p.low[:n]),
+		high:      slices.Clone(p.high[:n]),
+		bearColor: p.bearColor,
+	}
+
+	if p.ohlc {
+		result.kind = plotSeriesOHLC
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package giu
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_exportValues(t *testing.T) {
+	xs, ys := exportValues([]int16{1, 2, 3}, 0.5, 10, 1)
+	assert.Equal(t, []float64{10, 10.5, 11}, xs)
+	assert.Equal(t, []float64{2, 3, 1}, ys, "values should be rotated by offset")
+
+	xs, ys = exportXY([]float32{1, 2, 3}, []float32{4, 5}, 1)
+	assert.Equal(t, []float64{2, 1}, xs, "points should be limited to the shorter slice")
+	assert.Equal(t, []float64{5, 4}, ys)
+}
+
+func Test_exportSeries(t *testing.T) {
+	series, err := exportSeries([]PlotWidget{Custom(nil), &DragLineXPlot{}, Style().Plots(&TagPlot{})})
+	require.NoError(t, err, "tools and custom widgets should be skipped")
+	assert.Empty(t, series)
+
+	_, err = exportSeries([]PlotWidget{Custom(nil), Style().Plots(PieChart(nil, nil, 0, 0, 1))})
+	assert.ErrorIs(t, err, ErrPlotSeriesNotExportable, "nested pie chart should not be silently skipped")
+}
+
+func Test_writePlotCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, writePlotCSV(buf, []plotSeriesData{
+		{label: "line##1", kind: plotSeriesLine, xs: []float64{0, 1}, ys: []float64{2, 3}},
+		{
+			label: "prices", kind: plotSeriesCandles, xs: []float64{10}, ys: []float64{5},
+			open: []float64{4}, low: []float64{3}, high: []float64{6},
+		},
+	}))
+
+	assert.Equal(t, "series,x,y,open,low,high\nline,0,2,,,\nline,1,3,,,\nprices,10,5,4,3,6\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writePlotCSV(buf, []plotSeriesData{
+		{label: "line", kind: plotSeriesLine, xs: []float64{0}, ys: []float64{2}},
+	}))
+
+	assert.Equal(t, "series,x,y\nline,0,2\n", buf.String(), "price columns should be added only for candles")
+}
+
+func testPlotScene() *plotScene {
+	axis := plotSceneAxis{min: 0, max: 10, format: defaultPlotFormatter(PlotScaleLinear, 0, 10, false)}
+
+	return &plotScene{
+		title: "Test",
+		x:     axis,
+		y:     [3]plotSceneAxis{axis, axis, axis},
+		series: []plotSeriesData{
+			{label: "line##1", kind: plotSeriesLine, xs: []float64{0, 5, 10}, ys: []float64{1, 9, 1}},
+			{label: "bars", kind: plotSeriesBars, xs: []float64{2, 4}, ys: []float64{3, 5}, size: 0.5},
+		},
+		colors: []color.NRGBA{{255, 0, 0, 255}, {0, 0, 255, 128}},
+	}
+}
+
+func TestPlotScene_renderSVG(t *testing.T) {
+	painter := newSVGPainter(400, 300)
+	testPlotScene().render(painter, 400, 300)
+	svg := string(painter.bytes())
+
+	assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg" width="400" height="300"`)
+	assert.Contains(t, svg, `stroke="#ff0000"`, "line should be drawn")
+	assert.Contains(t, svg, `fill="rgba(0,0,255,0.502)"`, "bars should be drawn")
+	assert.Contains(t, svg, ">line</text>", "legend should show label without ID")
+	assert.Contains(t, svg, ">Test</text>", "title should be drawn")
+}
+
+func TestPlotScene_renderCandles(t *testing.T) {
+	scene := testPlotScene()
+	scene.series = []plotSeriesData{{
+		label: "prices", kind: plotSeriesCandles, xs: []float64{3, 6}, ys: []float64{7, 2}, size: 1,
+		open: []float64{2, 7}, low: []float64{1, 1}, high: []float64{8, 8},
+		bearColor: color.RGBA{R: 255, A: 255},
+	}}
+	scene.colors = []color.NRGBA{{0, 255, 0, 255}}
+
+	painter := newSVGPainter(400, 300)
+	scene.render(painter, 400, 300)
+	svg := string(painter.bytes())
+
+	assert.Contains(t, svg, `fill="#00ff00"`, "rising candle should use the series color")
+	assert.Contains(t, svg, `fill="#ff0000"`, "falling candle should use the bear color")
+}
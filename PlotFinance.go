@@ -0,0 +1,267 @@
+package giu
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// Default colors of rising (bull) and falling (bear) candles.
+var (
+	plotBullColor = color.RGBA{R: 0, G: 255, B: 113, A: 255}
+	plotBearColor = color.RGBA{R: 218, G: 13, B: 79, A: 255}
+)
+
+// CandlestickPlot represents a financial chart of open, close, low and high prices.
+// It is drawn either as candlesticks or as OHLC bars.
+type CandlestickPlot struct {
+	title                    string
+	dates                    []float64
+	open, closing, low, high []float64
+	width                    float64
+	bullColor, bearColor     color.Color
+	tooltip                  bool
+	ohlc                     bool
+	yAxis                    ImPlotYAxis
+
+	style plotSeriesStyle
+}
+
+// Candlestick adds a candlestick chart to the canvas.
+// Dates are values of the time axis (see TimeToPlotValue) sorted in ascending order;
+// the prices of i-th candle are open[i], closing[i], low[i] and high[i].
+// Volume bars may be added on a secondary y axis with BarOf(...).XValues(dates).SetPlotYAxis(...).
+func Candlestick(title string, dates, open, closing, low, high []float64) *CandlestickPlot {
+	return &CandlestickPlot{
+		title:     title,
+		dates:     dates,
+		open:      open,
+		closing:   closing,
+		low:       low,
+		high:      high,
+		width:     0.5,
+		bullColor: plotBullColor,
+		bearColor: plotBearColor,
+		tooltip:   true,
+	}
+}
+
+// OHLC adds an OHLC chart to the canvas. Each bar is a vertical line from low to high price
+// with open price marked on the left and close price on the right.
+// Arguments are the same as in Candlestick.
+func OHLC(title string, dates, open, closing, low, high []float64) *CandlestickPlot {
+	p := Candlestick(title, dates, open, closing, low, high)
+	p.ohlc = true
+
+	return p
+}
+
+// Width sets width of candles as a fraction (0-1) of the smallest distance between dates.
+func (p *CandlestickPlot) Width(width float64) *CandlestickPlot {
+	p.width = width
+	return p
+}
+
+// BullColor sets color of candles which closed higher than they opened.
+func (p *CandlestickPlot) BullColor(col color.Color) *CandlestickPlot {
+	p.bullColor = col
+	return p
+}
+
+// BearColor sets color of candles which closed lower than they opened.
+func (p *CandlestickPlot) BearColor(col color.Color) *CandlestickPlot {
+	p.bearColor = col
+	return p
+}
+
+// Tooltip enables (default) or disables the tooltip showing prices of the hovered candle.
+func (p *CandlestickPlot) Tooltip(tooltip bool) *CandlestickPlot {
+	p.tooltip = tooltip
+	return p
+}
+
+// SetPlotYAxis sets yAxis parameters.
+func (p *CandlestickPlot) SetPlotYAxis(yAxis ImPlotYAxis) *CandlestickPlot {
+	p.yAxis = yAxis
+	return p
+}
+
+// LineWeight sets weight (in pixels) of wicks and OHLC bars.
+func (p *CandlestickPlot) LineWeight(weight float32) *CandlestickPlot {
+	p.style.lineWeight = weight
+	return p
+}
+
+// Hidden binds series visibility to *hidden.
+// The value is updated when the user toggles the series in the legend.
+func (p *CandlestickPlot) Hidden(hidden *bool) *CandlestickPlot {
+	p.style.hidden = hidden
+	return p
+}
+
+// count returns number of complete candles.
+func (p *CandlestickPlot) count() int {
+	return min(len(p.dates), len(p.open), len(p.closing), len(p.low), len(p.high))
+}
+
+// Plot implements Plot interface.
+func (p *CandlestickPlot) Plot() {
+	defer p.style.setNext(p.title)()
+
+	xAxis, yAxis := setPlotYAxis(p.yAxis)
+
+	if !implot.BeginItem(Context.PrepareString(p.title)) {
+		return
+	}
+
+	defer implot.EndItem()
+
+	// legend entry uses the bull color
+	implot.GetCurrentItem().SetColor(ColorToUint(p.bullColor))
+
+	n := p.count()
+	dates := p.dates[:n]
+	halfWidth := candleHalfWidth(dates, p.width)
+
+	if implot.FitThisFrame() {
+		for i, date := range dates {
+			implot.FitPoint(implot.PlotPoint{X: date - halfWidth, Y: p.low[i]})
+			implot.FitPoint(implot.PlotPoint{X: date + halfWidth, Y: p.high[i]})
+		}
+	}
+
+	if p.tooltip {
+		p.handleHover(dates, halfWidth, xAxis, yAxis)
+	}
+
+	drawList := implot.GetPlotDrawList()
+	weight := max(p.style.lineWeight, 1)
+
+	toPixels := func(x, y float64) imgui.Vec2 {
+		return implot.PlotToPixelsdoubleV(x, y, xAxis, yAxis)
+	}
+
+	for i, date := range dates {
+		col := ColorToUint(p.candleColor(i))
+		low := toPixels(date, p.low[i])
+		high := toPixels(date, p.high[i])
+
+		drawList.AddLineV(low, high, col, weight)
+
+		if p.ohlc {
+			drawList.AddLineV(
+				toPixels(date-halfWidth, p.open[i]),
+				toPixels(date, p.open[i]),
+				col, weight,
+			)
+			drawList.AddLineV(
+				toPixels(date, p.closing[i]),
+				toPixels(date+halfWidth, p.closing[i]),
+				col, weight,
+			)
+
+			continue
+		}
+
+		drawList.AddRectFilled(
+			toPixels(date-halfWidth, p.open[i]),
+			toPixels(date+halfWidth, p.closing[i]),
+			col,
+		)
+	}
+}
+
+// candleColor returns color of i-th candle.
+func (p *CandlestickPlot) candleColor(i int) color.Color {
+	if p.closing[i] >= p.open[i] {
+		return p.bullColor
+	}
+
+	return p.bearColor
+}
+
+// handleHover highlights the hovered candle and shows a tooltip with its prices.
+// xAxis and yAxis are axes of the plot.
+func (p *CandlestickPlot) handleHover(dates []float64, halfWidth float64, xAxis, yAxis PlotAxis) {
+	if len(dates) == 0 || !implot.IsPlotHovered() {
+		return
+	}
+
+	mouse := implot.GetPlotMousePosV(xAxis, yAxis)
+
+	i := nearestDate(dates, mouse.X)
+	if math.Abs(mouse.X-dates[i]) > halfWidth {
+		return
+	}
+
+	highlight := Vec4ToRGBA(ToVec4Color(p.candleColor(i)))
+	highlight.A = 64
+	limits := implot.GetPlotLimitsV(xAxis, yAxis)
+	yRange := limits.Y()
+
+	implot.GetPlotDrawList().AddRectFilled(
+		implot.PlotToPixelsdoubleV(dates[i]-halfWidth, yRange.Min(), xAxis, yAxis),
+		implot.PlotToPixelsdoubleV(dates[i]+halfWidth, yRange.Max(), xAxis, yAxis),
+		ColorToUint(highlight),
+	)
+
+	imgui.SetTooltip(fmt.Sprintf(
+		"%s\nOpen:  %g\nClose: %g\nLow:   %g\nHigh:  %g",
+		candleDate(dates[i]), p.open[i], p.closing[i], p.low[i], p.high[i],
+	))
+}
+
+// candleDate formats date of a candle. Time of day is omitted for daily candles.
+func candleDate(value float64) string {
+	t := PlotValueToTime(value)
+	if currentPlotCanvas == nil || !currentPlotCanvas.timeFormat.local {
+		t = t.UTC()
+	}
+
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format(time.DateOnly)
+	}
+
+	return t.Format(time.DateTime)
+}
+
+// candleHalfWidth returns half of candles' width so that they fill width (0-1) of the smallest gap
+// between dates. A single candle is one day wide.
+func candleHalfWidth(dates []float64, width float64) float64 {
+	const day = 24 * 60 * 60
+
+	gap := math.Inf(1)
+
+	for i := 1; i < len(dates); i++ {
+		if d := dates[i] - dates[i-1]; d > 0 && d < gap {
+			gap = d
+		}
+	}
+
+	if math.IsInf(gap, 1) {
+		gap = day
+	}
+
+	return gap * width / 2
+}
+
+// nearestDate returns index of the date closest to x. dates must be sorted and not empty.
+func nearestDate(dates []float64, x float64) int {
+	i := sort.SearchFloat64s(dates, x)
+
+	switch {
+	case i == 0:
+		return 0
+	case i == len(dates):
+		return len(dates) - 1
+	case x-dates[i-1] <= dates[i]-x:
+		return i - 1
+	default:
+		return i
+	}
+}
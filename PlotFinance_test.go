@@ -0,0 +1,36 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_candleHalfWidth(t *testing.T) {
+	const day = 24 * 60 * 60
+
+	// weekend gap doesn't make candles wider
+	dates := []float64{0, day, 2 * day, 5 * day}
+	assert.InDelta(t, day/4, candleHalfWidth(dates, 0.5), 1e-9)
+	assert.InDelta(t, day/2, candleHalfWidth([]float64{day}, 1), 1e-9, "single candle should be one day wide")
+	assert.InDelta(t, day/4, candleHalfWidth(nil, 0.5), 1e-9)
+}
+
+func Test_nearestDate(t *testing.T) {
+	dates := []float64{10, 20, 40}
+
+	tests := []struct {
+		x        float64
+		expected int
+	}{
+		{0, 0},
+		{14, 0},
+		{16, 1},
+		{31, 2},
+		{100, 2},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, nearestDate(dates, tt.x), "x = %v", tt.x)
+	}
+}
@@ -0,0 +1,216 @@
+package giu
+
+import (
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// PlotNumber is a numeric type that can be plotted without converting it to float64.
+type PlotNumber interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~int64 | ~uint64 | ~int | ~uint | ~float32 | ~float64
+}
+
+// plotKind returns kind of T. int and uint are reported as fixed-size integers of the same size.
+func plotKind[T PlotNumber]() reflect.Kind {
+	kind := reflect.TypeFor[T]().Kind()
+
+	switch {
+	case kind == reflect.Int && strconv.IntSize == 64:
+		return reflect.Int64
+	case kind == reflect.Int:
+		return reflect.Int32
+	case kind == reflect.Uint && strconv.IntSize == 64:
+		return reflect.Uint64
+	case kind == reflect.Uint:
+		return reflect.Uint32
+	}
+
+	return kind
+}
+
+// plotPtr returns pointer to the first element of s reinterpreted as *U (or nil if s is empty).
+func plotPtr[U, T any](s []T) *U {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return (*U)(unsafe.Pointer(&s[0]))
+}
+
+// plotStride returns size of T in bytes.
+func plotStride[T any]() int32 {
+	var zero T
+
+	return int32(unsafe.Sizeof(zero))
+}
+
+// plotLineValues plots values placed at x = x0 + i * xScale as a line.
+func plotLineValues[T PlotNumber](label string, values []T, xScale, x0 float64, flags implot.LineFlags, offset int, stride int32) {
+	count := int32(len(values))
+
+	switch plotKind[T]() {
+	case reflect.Int8:
+		implot.PlotLineS8PtrIntV(label, plotPtr[int8](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint8:
+		implot.PlotLineU8PtrIntV(label, plotPtr[byte](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Int16:
+		implot.PlotLineS16PtrIntV(label, plotPtr[int16](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint16:
+		implot.PlotLineU16PtrIntV(label, plotPtr[uint16](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Int32:
+		implot.PlotLineS32PtrIntV(label, plotPtr[int32](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint32:
+		implot.PlotLineU32PtrIntV(label, plotPtr[uint32](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Int64:
+		implot.PlotLineS64PtrIntV(label, plotPtr[int64](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint64:
+		implot.PlotLineU64PtrIntV(label, plotPtr[uint64](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Float32:
+		implot.PlotLineFloatPtrIntV(label, plotPtr[float32](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Float64:
+		implot.PlotLinedoublePtrIntV(label, plotPtr[float64](values), count, xScale, x0, flags, int32(offset), stride)
+	}
+}
+
+// plotScatterValues plots values placed at x = x0 + i * xScale as a scatter plot.
+func plotScatterValues[T PlotNumber](label string, values []T, xScale, x0 float64, flags implot.ScatterFlags, offset int, stride int32) {
+	count := int32(len(values))
+
+	switch plotKind[T]() {
+	case reflect.Int8:
+		implot.PlotScatterS8PtrIntV(label, plotPtr[int8](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint8:
+		implot.PlotScatterU8PtrIntV(label, plotPtr[byte](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Int16:
+		implot.PlotScatterS16PtrIntV(label, plotPtr[int16](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint16:
+		implot.PlotScatterU16PtrIntV(label, plotPtr[uint16](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Int32:
+		implot.PlotScatterS32PtrIntV(label, plotPtr[int32](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint32:
+		implot.PlotScatterU32PtrIntV(label, plotPtr[uint32](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Int64:
+		implot.PlotScatterS64PtrIntV(label, plotPtr[int64](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Uint64:
+		implot.PlotScatterU64PtrIntV(label, plotPtr[uint64](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Float32:
+		implot.PlotScatterFloatPtrIntV(label, plotPtr[float32](values), count, xScale, x0, flags, int32(offset), stride)
+	case reflect.Float64:
+		implot.PlotScatterdoublePtrIntV(label, plotPtr[float64](values), count, xScale, x0, flags, int32(offset), stride)
+	}
+}
+
+// plotBarsValues plots values placed at x = i as bars.
+func plotBarsValues[T PlotNumber](label string, values []T, barSize, shift float64, flags implot.BarsFlags, offset int, stride int32) {
+	count := int32(len(values))
+
+	switch plotKind[T]() {
+	case reflect.Int8:
+		implot.PlotBarsS8PtrIntV(label, plotPtr[int8](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Uint8:
+		implot.PlotBarsU8PtrIntV(label, plotPtr[byte](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Int16:
+		implot.PlotBarsS16PtrIntV(label, plotPtr[int16](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Uint16:
+		implot.PlotBarsU16PtrIntV(label, plotPtr[uint16](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Int32:
+		implot.PlotBarsS32PtrIntV(label, plotPtr[int32](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Uint32:
+		implot.PlotBarsU32PtrIntV(label, plotPtr[uint32](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Int64:
+		implot.PlotBarsS64PtrIntV(label, plotPtr[int64](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Uint64:
+		implot.PlotBarsU64PtrIntV(label, plotPtr[uint64](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Float32:
+		implot.PlotBarsFloatPtrIntV(label, plotPtr[float32](values), count, barSize, shift, flags, int32(offset), stride)
+	case reflect.Float64:
+		implot.PlotBarsdoublePtrIntV(label, plotPtr[float64](values), count, barSize, shift, flags, int32(offset), stride)
+	}
+}
+
+// plotBarsXY plots bars of height ys[i] placed at xs[i].
+func plotBarsXY[T PlotNumber](label string, xs, ys []T, barSize float64, flags implot.BarsFlags, offset int) {
+	count, stride := int32(min(len(xs), len(ys))), plotStride[T]()
+
+	switch plotKind[T]() {
+	case reflect.Int8:
+		implot.PlotBarsS8PtrS8PtrV(label, plotPtr[int8](xs), plotPtr[int8](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Uint8:
+		implot.PlotBarsU8PtrU8PtrV(label, plotPtr[byte](xs), plotPtr[byte](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Int16:
+		implot.PlotBarsS16PtrS16PtrV(label, plotPtr[int16](xs), plotPtr[int16](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Uint16:
+		implot.PlotBarsU16PtrU16PtrV(label, plotPtr[uint16](xs), plotPtr[uint16](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Int32:
+		implot.PlotBarsS32PtrS32PtrV(label, plotPtr[int32](xs), plotPtr[int32](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Uint32:
+		implot.PlotBarsU32PtrU32PtrV(label, plotPtr[uint32](xs), plotPtr[uint32](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Int64:
+		implot.PlotBarsS64PtrS64PtrV(label, plotPtr[int64](xs), plotPtr[int64](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Uint64:
+		implot.PlotBarsU64PtrU64PtrV(label, plotPtr[uint64](xs), plotPtr[uint64](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Float32:
+		implot.PlotBarsFloatPtrFloatPtrV(label, plotPtr[float32](xs), plotPtr[float32](ys), count, barSize, flags, int32(offset), stride)
+	case reflect.Float64:
+		implot.PlotBarsdoublePtrdoublePtrV(label, plotPtr[float64](xs), plotPtr[float64](ys), count, barSize, flags, int32(offset), stride)
+	}
+}
+
+// plotLineXY plots (xs[i], ys[i]) points as a line.
+func plotLineXY[T PlotNumber](label string, xs, ys []T, flags implot.LineFlags, offset int) {
+	count, stride := int32(min(len(xs), len(ys))), plotStride[T]()
+
+	switch plotKind[T]() {
+	case reflect.Int8:
+		implot.PlotLineS8PtrS8PtrV(label, plotPtr[int8](xs), plotPtr[int8](ys), count, flags, int32(offset), stride)
+	case reflect.Uint8:
+		implot.PlotLineU8PtrU8PtrV(label, plotPtr[byte](xs), plotPtr[byte](ys), count, flags, int32(offset), stride)
+	case reflect.Int16:
+		implot.PlotLineS16PtrS16PtrV(label, plotPtr[int16](xs), plotPtr[int16](ys), count, flags, int32(offset), stride)
+	case reflect.Uint16:
+		implot.PlotLineU16PtrU16PtrV(label, plotPtr[uint16](xs), plotPtr[uint16](ys), count, flags, int32(offset), stride)
+	case reflect.Int32:
+		implot.PlotLineS32PtrS32PtrV(label, plotPtr[int32](xs), plotPtr[int32](ys), count, flags, int32(offset), stride)
+	case reflect.Uint32:
+		implot.PlotLineU32PtrU32PtrV(label, plotPtr[uint32](xs), plotPtr[uint32](ys), count, flags, int32(offset), stride)
+	case reflect.Int64:
+		implot.PlotLineS64PtrS64PtrV(label, plotPtr[int64](xs), plotPtr[int64](ys), count, flags, int32(offset), stride)
+	case reflect.Uint64:
+		implot.PlotLineU64PtrU64PtrV(label, plotPtr[uint64](xs), plotPtr[uint64](ys), count, flags, int32(offset), stride)
+	case reflect.Float32:
+		implot.PlotLineFloatPtrFloatPtrV(label, plotPtr[float32](xs), plotPtr[float32](ys), count, flags, int32(offset), stride)
+	case reflect.Float64:
+		implot.PlotLinedoublePtrdoublePtrV(label, plotPtr[float64](xs), plotPtr[float64](ys), count, flags, int32(offset), stride)
+	}
+}
+
+// plotScatterXY plots (xs[i], ys[i]) points as a scatter plot.
+func plotScatterXY[T PlotNumber](label string, xs, ys []T, flags implot.ScatterFlags, offset int) {
+	count, stride := int32(min(len(xs), len(ys))), plotStride[T]()
+
+	switch plotKind[T]() {
+	case reflect.Int8:
+		implot.PlotScatterS8PtrS8PtrV(label, plotPtr[int8](xs), plotPtr[int8](ys), count, flags, int32(offset), stride)
+	case reflect.Uint8:
+		implot.PlotScatterU8PtrU8PtrV(label, plotPtr[byte](xs), plotPtr[byte](ys), count, flags, int32(offset), stride)
+	case reflect.Int16:
+		implot.PlotScatterS16PtrS16PtrV(label, plotPtr[int16](xs), plotPtr[int16](ys), count, flags, int32(offset), stride)
+	case reflect.Uint16:
+		implot.PlotScatterU16PtrU16PtrV(label, plotPtr[uint16](xs), plotPtr[uint16](ys), count, flags, int32(offset), stride)
+	case reflect.Int32:
+		implot.PlotScatterS32PtrS32PtrV(label, plotPtr[int32](xs), plotPtr[int32](ys), count, flags, int32(offset), stride)
+	case reflect.Uint32:
+		implot.PlotScatterU32PtrU32PtrV(label, plotPtr[uint32](xs), plotPtr[uint32](ys), count, flags, int32(offset), stride)
+	case reflect.Int64:
+		implot.PlotScatterS64PtrS64PtrV(label, plotPtr[int64](xs), plotPtr[int64](ys), count, flags, int32(offset), stride)
+	case reflect.Uint64:
+		implot.PlotScatterU64PtrU64PtrV(label, plotPtr[uint64](xs), plotPtr[uint64](ys), count, flags, int32(offset), stride)
+	case reflect.Float32:
+		implot.PlotScatterFloatPtrFloatPtrV(label, plotPtr[float32](xs), plotPtr[float32](ys), count, flags, int32(offset), stride)
+	case reflect.Float64:
+		implot.PlotScatterdoublePtrdoublePtrV(label, plotPtr[float64](xs), plotPtr[float64](ys), count, flags, int32(offset), stride)
+	}
+}
@@ -0,0 +1,32 @@
+package giu
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSample int16
+
+func Test_plotKind(t *testing.T) {
+	intKind, uintKind := reflect.Int64, reflect.Uint64
+	if strconv.IntSize == 32 {
+		intKind, uintKind = reflect.Int32, reflect.Uint32
+	}
+
+	assert.Equal(t, reflect.Int16, plotKind[int16]())
+	assert.Equal(t, reflect.Int16, plotKind[testSample](), "named types should be reported as their underlying type")
+	assert.Equal(t, reflect.Float32, plotKind[float32]())
+	assert.Equal(t, intKind, plotKind[int]())
+	assert.Equal(t, uintKind, plotKind[uint]())
+}
+
+func Test_plotPtr(t *testing.T) {
+	assert.Nil(t, plotPtr[int16]([]testSample{}))
+
+	values := []testSample{1, 2, 3}
+	assert.Equal(t, int16(1), *plotPtr[int16](values))
+	assert.Equal(t, int32(2), plotStride[testSample]())
+}
@@ -0,0 +1,482 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// plotPalette is implot's default (Deep) colormap used for series without known color.
+var plotPalette = []color.NRGBA{
+	{76, 114, 176, 255},
+	{221, 132, 82, 255},
+	{85, 168, 104, 255},
+	{196, 78, 82, 255},
+	{129, 114, 179, 255},
+	{147, 120, 96, 255},
+	{218, 139, 195, 255},
+	{140, 140, 140, 255},
+	{204, 185, 116, 255},
+	{100, 181, 205, 255},
+}
+
+var (
+	plotTextColor   = color.NRGBA{0, 0, 0, 255}
+	plotGridColor   = color.NRGBA{220, 220, 220, 255}
+	plotBorderColor = color.NRGBA{100, 100, 100, 255}
+	plotBackground  = color.NRGBA{255, 255, 255, 255}
+)
+
+// approximate size of the exported text.
+const (
+	plotCharWidth  = 7
+	plotLineHeight = 13
+)
+
+type plotTextAnchor byte
+
+const (
+	plotTextStart plotTextAnchor = iota
+	plotTextMiddle
+	plotTextEnd
+)
+
+type plotPoint struct {
+	x, y float64
+}
+
+// plotPainter draws primitives of an exported plot.
+type plotPainter interface {
+	// clip limits following drawing to the rectangle (or removes the limit if r is nil).
+	clip(r *image.Rectangle)
+	polyline(points []plotPoint, col color.NRGBA, width float64)
+	fillRect(r image.Rectangle, col color.NRGBA)
+	circle(center plotPoint, radius float64, col color.NRGBA)
+	// text draws s with baseline at y.
+	text(x, y float64, s string, col color.NRGBA, anchor plotTextAnchor)
+}
+
+// plotSceneAxis is an axis of exported plot.
+type plotSceneAxis struct {
+	label    string
+	min, max float64
+	scale    PlotScale
+	format   PlotFormatter
+}
+
+// transform maps v to [0, 1] range of the axis.
+func (a *plotSceneAxis) transform(v float64) float64 {
+	if a.scale == PlotScaleLog10 && a.min > 0 && a.max > 0 {
+		return (math.Log10(v) - math.Log10(a.min)) / (math.Log10(a.max) - math.Log10(a.min))
+	}
+
+	return (v - a.min) / (a.max - a.min)
+}
+
+// plotScene is a plot prepared for exporting.
+type plotScene struct {
+	title  string
+	x      plotSceneAxis
+	y      [3]plotSceneAxis
+	series []plotSeriesData
+	colors []color.NRGBA
+}
+
+// exportScene prepares the plot for exporting using ranges and colors of the last frame.
+func (p *PlotCanvasWidget) exportScene() (*plotScene, error) {
+	series, err := exportSeries(p.plots)
+	if err != nil {
+		return nil, err
+	}
+
+	state := p.getState()
+
+	scene := &plotScene{title: plotTitle(p.title)}
+
+	newAxis := func(axis PlotAxis) plotSceneAxis {
+		a := &p.axes[axis]
+		result := plotSceneAxis{label: a.label, scale: a.scale, format: a.formatter}
+
+		switch {
+		case state.recorded:
+			result.min, result.max = state.ranges[axis].Min, state.ranges[axis].Max
+		case a.limits != nil:
+			result.min, result.max = a.limits.min, a.limits.max
+		}
+
+		if result.format == nil {
+			result.format = defaultPlotFormatter(a.scale, result.min, result.max, p.timeFormat.local)
+		}
+
+		return result
+	}
+
+	scene.x = newAxis(AxisX1)
+	for i := range scene.y {
+		scene.y[i] = newAxis(AxisY1 + PlotAxis(i))
+	}
+
+	for i, series := range series {
+		if visible, ok := state.visible[series.label]; ok && !visible {
+			continue
+		}
+
+		col, ok := state.colors[series.label]
+
+		switch {
+		case series.color != nil:
+			col = color.NRGBA(Vec4ToRGBA(ToVec4Color(series.color)))
+		case !ok:
+			col = plotPalette[i%len(plotPalette)]
+		}
+
+		scene.series = append(scene.series, series)
+		scene.colors = append(scene.colors, col)
+	}
+
+	return scene, nil
+}
+
+// defaultPlotFormatter returns formatter of tick labels of exported axis.
+func defaultPlotFormatter(scale PlotScale, minimum, maximum float64, local bool) PlotFormatter {
+	if scale != PlotScaleTime {
+		return func(value float64) string {
+			return strconv.FormatFloat(value, 'g', 6, 64)
+		}
+	}
+
+	layout := time.TimeOnly
+	if maximum-minimum > 24*60*60 {
+		layout = time.DateOnly
+	}
+
+	return func(value float64) string {
+		t := PlotValueToTime(value)
+		if !local {
+			t = t.UTC()
+		}
+
+		return t.Format(layout)
+	}
+}
+
+// render draws the scene to width x height image.
+func (s *plotScene) render(painter plotPainter, width, height int) {
+	const margin = 10
+
+	painter.fillRect(image.Rect(0, 0, width, height), plotBackground)
+
+	area := image.Rect(7*plotCharWidth+margin, margin, width-margin, height-2*plotLineHeight-margin)
+
+	if s.title != "" {
+		area.Min.Y += plotLineHeight + margin
+		painter.text(float64(width)/2, margin+plotLineHeight, s.title, plotTextColor, plotTextMiddle)
+	}
+
+	if s.y[1].label != "" {
+		area.Max.X -= 7 * plotCharWidth
+	}
+
+	if area.Dx() <= 0 || area.Dy() <= 0 {
+		return
+	}
+
+	toPixel := func(x, y float64, yAxis ImPlotYAxis) plotPoint {
+		return plotPoint{
+			x: float64(area.Min.X) + s.x.transform(x)*float64(area.Dx()),
+			y: float64(area.Max.Y) - s.y[yAxis].transform(y)*float64(area.Dy()),
+		}
+	}
+
+	s.renderAxes(painter, area)
+
+	painter.clip(&area)
+
+	for i, series := range s.series {
+		renderPlotSeries(painter, &series, s.colors[i], func(x, y float64) plotPoint {
+			return toPixel(x, y, series.yAxis)
+		})
+	}
+
+	painter.clip(nil)
+
+	s.renderLegend(painter, area)
+}
+
+// renderAxes draws grid, ticks and labels of the axes and border of the plot area.
+func (s *plotScene) renderAxes(painter plotPainter, area image.Rectangle) {
+	left, right := float64(area.Min.X), float64(area.Max.X)
+	top, bottom := float64(area.Min.Y), float64(area.Max.Y)
+
+	for _, v := range plotTickValues(s.x.min, s.x.max, s.x.scale, float32(area.Dx()), false) {
+		x := left + s.x.transform(v)*float64(area.Dx())
+		painter.polyline([]plotPoint{{x, top}, {x, bottom}}, plotGridColor, 1)
+		painter.text(x, bottom+plotLineHeight, s.x.format(v), plotTextColor, plotTextMiddle)
+	}
+
+	// only Y1 and Y2 axes are drawn (on the left and right side).
+	for i, y := range s.y[:2] {
+		if i > 0 && y.label == "" {
+			continue
+		}
+
+		for _, v := range plotTickValues(y.min, y.max, y.scale, float32(area.Dy()), true) {
+			py := bottom - y.transform(v)*float64(area.Dy())
+
+			if i == 0 {
+				painter.polyline([]plotPoint{{left, py}, {right, py}}, plotGridColor, 1)
+				painter.text(left-4, py+4, y.format(v), plotTextColor, plotTextEnd)
+			} else {
+				painter.text(right+4, py+4, y.format(v), plotTextColor, plotTextStart)
+			}
+		}
+	}
+
+	painter.polyline([]plotPoint{{left, top}, {right, top}, {right, bottom}, {left, bottom}, {left, top}}, plotBorderColor, 1)
+
+	painter.text((left+right)/2, bottom+2*plotLineHeight+4, s.x.label, plotTextColor, plotTextMiddle)
+	painter.text(left, top-4, s.y[0].label, plotTextColor, plotTextStart)
+	painter.text(right, top-4, s.y[1].label, plotTextColor, plotTextEnd)
+}
+
+// renderLegend draws legend in the top-left corner of the plot area.
+func (s *plotScene) renderLegend(painter plotPainter, area image.Rectangle) {
+	if len(s.series) == 0 {
+		return
+	}
+
+	const (
+		padding = 5
+		swatch  = 10
+	)
+
+	longest := 0
+	for _, series := range s.series {
+		longest = max(longest, utf8.RuneCountInString(plotTitle(series.label)))
+	}
+
+	box := image.Rect(0, 0, 3*padding+swatch+longest*plotCharWidth, padding+len(s.series)*(plotLineHeight+padding)).
+		Add(area.Min).Add(image.Pt(padding, padding))
+
+	painter.fillRect(box, plotBackground)
+	painter.polyline([]plotPoint{
+		{float64(box.Min.X), float64(box.Min.Y)},
+		{float64(box.Max.X), float64(box.Min.Y)},
+		{float64(box.Max.X), float64(box.Max.Y)},
+		{float64(box.Min.X), float64(box.Max.Y)},
+		{float64(box.Min.X), float64(box.Min.Y)},
+	}, plotBorderColor, 1)
+
+	for i, series := range s.series {
+		y := box.Min.Y + padding + i*(plotLineHeight+padding)
+		painter.fillRect(image.Rect(box.Min.X+padding, y+1, box.Min.X+padding+swatch, y+1+swatch), s.colors[i])
+		painter.text(float64(box.Min.X+2*padding+swatch), float64(y+plotLineHeight-2), plotTitle(series.label), plotTextColor, plotTextStart)
+	}
+}
+
+// renderPlotSeries draws series using toPixel to convert its points to pixels.
+func renderPlotSeries(painter plotPainter, series *plotSeriesData, col color.NRGBA, toPixel func(x, y float64) plotPoint) {
+	const (
+		lineWidth    = 1.5
+		markerRadius = 3
+	)
+
+	xs, ys := series.xs, series.ys
+
+	switch series.kind {
+	case plotSeriesLine:
+		renderPlotLine(painter, len(xs), func(i int) (float64, float64) { return xs[i], ys[i] }, col, lineWidth, toPixel)
+	case plotSeriesStairs, plotSeriesStairsPre:
+		// each point becomes two corners of the step
+		renderPlotLine(painter, 2*len(xs)-1, func(i int) (float64, float64) {
+			j := (i + 1) / 2
+			if i%2 == 0 {
+				return xs[j], ys[j]
+			}
+
+			if series.kind == plotSeriesStairsPre {
+				return xs[j-1], ys[j]
+			}
+
+			return xs[j], ys[j-1]
+		}, col, lineWidth, toPixel)
+	case plotSeriesScatter:
+		for i := range xs {
+			painter.circle(toPixel(xs[i], ys[i]), markerRadius, col)
+		}
+	case plotSeriesStems, plotSeriesStemsH:
+		for i := range xs {
+			from := toPixel(xs[i], series.size)
+			if series.kind == plotSeriesStemsH {
+				from = toPixel(series.size, ys[i])
+			}
+
+			to := toPixel(xs[i], ys[i])
+			painter.polyline([]plotPoint{from, to}, col, lineWidth)
+			painter.circle(to, markerRadius, col)
+		}
+	case plotSeriesBars, plotSeriesBarsH:
+		for i := range xs {
+			var a, b plotPoint
+			if series.kind == plotSeriesBars {
+				a, b = toPixel(xs[i]-series.size/2, 0), toPixel(xs[i]+series.size/2, ys[i])
+			} else {
+				a, b = toPixel(0, ys[i]-series.size/2), toPixel(xs[i], ys[i]+series.size/2)
+			}
+
+			painter.fillRect(image.Rect(int(a.x), int(a.y), int(b.x), int(b.y)).Canon(), col)
+		}
+	case plotSeriesCandles, plotSeriesOHLC:
+		renderPlotCandles(painter, series, col, toPixel)
+	}
+}
+
+// renderPlotCandles draws candlestick (or OHLC) series. col is the color of rising candles.
+func renderPlotCandles(painter plotPainter, series *plotSeriesData, col color.NRGBA, toPixel func(x, y float64) plotPoint) {
+	const lineWidth = 1
+
+	bearColor := col
+	if series.bearColor != nil {
+		bearColor = color.NRGBA(Vec4ToRGBA(ToVec4Color(series.bearColor)))
+	}
+
+	halfWidth := series.size / 2
+
+	for i, date := range series.xs {
+		c := col
+		if series.ys[i] < series.open[i] {
+			c = bearColor
+		}
+
+		painter.polyline([]plotPoint{toPixel(date, series.low[i]), toPixel(date, series.high[i])}, c, lineWidth)
+
+		if series.kind == plotSeriesOHLC {
+			painter.polyline([]plotPoint{toPixel(date-halfWidth, series.open[i]), toPixel(date, series.open[i])}, c, lineWidth)
+			painter.polyline([]plotPoint{toPixel(date, series.ys[i]), toPixel(date+halfWidth, series.ys[i])}, c, lineWidth)
+
+			continue
+		}
+
+		a, b := toPixel(date-halfWidth, series.open[i]), toPixel(date+halfWidth, series.ys[i])
+		painter.fillRect(image.Rect(int(a.x), int(a.y), int(b.x), int(b.y)).Canon(), c)
+	}
+}
+
+// renderPlotLine draws line through n points, skipping the ones that are not finite.
+func renderPlotLine(
+	painter plotPainter, n int, point func(i int) (x, y float64),
+	col color.NRGBA, width float64, toPixel func(x, y float64) plotPoint,
+) {
+	var run []plotPoint
+
+	for i := range n {
+		x, y := point(i)
+		if p := toPixel(x, y); isFinite(p.x) && isFinite(p.y) {
+			run = append(run, p)
+			continue
+		}
+
+		painter.polyline(run, col, width)
+		run = run[:0]
+	}
+
+	painter.polyline(run, col, width)
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// svgPainter draws plot as SVG image.
+type svgPainter struct {
+	buf     bytes.Buffer
+	clips   int
+	clipped bool
+}
+
+func newSVGPainter(width, height int) *svgPainter {
+	p := &svgPainter{}
+	fmt.Fprintf(&p.buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height,
+	)
+
+	return p
+}
+
+func (p *svgPainter) bytes() []byte {
+	p.clip(nil)
+	p.buf.WriteString("</svg>\n")
+
+	return p.buf.Bytes()
+}
+
+func (p *svgPainter) clip(r *image.Rectangle) {
+	if p.clipped {
+		p.buf.WriteString("</g>\n")
+		p.clipped = false
+	}
+
+	if r == nil {
+		return
+	}
+
+	p.clips++
+	fmt.Fprintf(&p.buf,
+		`<clipPath id="clip%d"><rect x="%d" y="%d" width="%d" height="%d"/></clipPath><g clip-path="url(#clip%d)">`+"\n",
+		p.clips, r.Min.X, r.Min.Y, r.Dx(), r.Dy(), p.clips,
+	)
+	p.clipped = true
+}
+
+func (p *svgPainter) polyline(points []plotPoint, col color.NRGBA, width float64) {
+	if len(points) < 2 {
+		return
+	}
+
+	p.buf.WriteString(`<polyline fill="none" points="`)
+
+	for i, point := range points {
+		if i > 0 {
+			p.buf.WriteByte(' ')
+		}
+
+		fmt.Fprintf(&p.buf, "%.2f,%.2f", point.x, point.y)
+	}
+
+	fmt.Fprintf(&p.buf, `" stroke="%s" stroke-width="%g"/>`+"\n", svgColor(col), width)
+}
+
+func (p *svgPainter) fillRect(r image.Rectangle, col color.NRGBA) {
+	fmt.Fprintf(&p.buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", r.Min.X, r.Min.Y, r.Dx(), r.Dy(), svgColor(col))
+}
+
+func (p *svgPainter) circle(center plotPoint, radius float64, col color.NRGBA) {
+	fmt.Fprintf(&p.buf, `<circle cx="%.2f" cy="%.2f" r="%g" fill="%s"/>`+"\n", center.x, center.y, radius, svgColor(col))
+}
+
+func (p *svgPainter) text(x, y float64, s string, col color.NRGBA, anchor plotTextAnchor) {
+	if s == "" {
+		return
+	}
+
+	anchors := [...]string{plotTextStart: "start", plotTextMiddle: "middle", plotTextEnd: "end"}
+
+	fmt.Fprintf(&p.buf, `<text x="%.2f" y="%.2f" fill="%s" text-anchor="%s">`, x, y, svgColor(col), anchors[anchor])
+	_ = xml.EscapeText(&p.buf, []byte(s)) // writing to bytes.Buffer never fails
+	p.buf.WriteString("</text>\n")
+}
+
+func svgColor(col color.NRGBA) string {
+	if col.A == math.MaxUint8 {
+		return fmt.Sprintf("#%02x%02x%02x", col.R, col.G, col.B)
+	}
+
+	return fmt.Sprintf("rgba(%d,%d,%d,%.3f)", col.R, col.G, col.B, float64(col.A)/math.MaxUint8)
+}
@@ -0,0 +1,200 @@
+package giu
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// PlotRingBuffer is a fixed-capacity buffer of (x, y) points for streaming (real-time) plots.
+// Once the buffer is full, new points overwrite the oldest ones.
+//
+// Points may be added from any goroutine, also while the buffer is being plotted.
+// Plots created by Line and LineXY read the buffer directly (using implot's offset),
+// so no data is copied each frame.
+//
+// When there are much more points visible than pixels in the plot,
+// they are decimated: only points with minimum and maximum y value of each pixel column are drawn.
+// This requires x values to be added in ascending order.
+type PlotRingBuffer struct {
+	mu     sync.RWMutex
+	xs, ys []float64
+	// start is an index of the oldest point.
+	start int
+
+	// decimated points (used by the rendering goroutine only).
+	decimatedX, decimatedY []float64
+}
+
+// NewPlotRingBuffer creates a new PlotRingBuffer holding up to capacity points.
+func NewPlotRingBuffer(capacity int) *PlotRingBuffer {
+	return &PlotRingBuffer{
+		xs: make([]float64, 0, capacity),
+		ys: make([]float64, 0, capacity),
+	}
+}
+
+// Add appends a new point to the buffer.
+func (b *PlotRingBuffer) Add(x, y float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.add(x, y)
+}
+
+// AddPoints appends (xs[i], ys[i]) points to the buffer.
+func (b *PlotRingBuffer) AddPoints(xs, ys []float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := range min(len(xs), len(ys)) {
+		b.add(xs[i], ys[i])
+	}
+}
+
+func (b *PlotRingBuffer) add(x, y float64) {
+	if cap(b.xs) == 0 {
+		return
+	}
+
+	if len(b.xs) < cap(b.xs) {
+		b.xs = append(b.xs, x)
+		b.ys = append(b.ys, y)
+
+		return
+	}
+
+	b.xs[b.start], b.ys[b.start] = x, y
+	b.start = (b.start + 1) % len(b.xs)
+}
+
+// Len returns number of points in the buffer.
+func (b *PlotRingBuffer) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.xs)
+}
+
+// Cap returns maximum number of points in the buffer.
+func (b *PlotRingBuffer) Cap() int {
+	return cap(b.xs)
+}
+
+// At returns i-th point of the buffer (0 is the oldest one).
+func (b *PlotRingBuffer) At(i int) (x, y float64) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	idx := b.index(i)
+
+	return b.xs[idx], b.ys[idx]
+}
+
+// Clear removes all points from the buffer.
+func (b *PlotRingBuffer) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.xs, b.ys = b.xs[:0], b.ys[:0]
+	b.start = 0
+}
+
+// Line creates LinePlot of buffer's y values.
+// Values are placed at x = X0 + i * XScale (x values of the buffer are ignored).
+func (b *PlotRingBuffer) Line(title string) *LinePlot {
+	p := Line(title, nil)
+	p.buffer = b
+
+	return p
+}
+
+// LineXY creates LineXYPlot of buffer's points.
+func (b *PlotRingBuffer) LineXY(title string) *LineXYPlot {
+	p := LineXY(title, nil, nil)
+	p.buffer = b
+
+	return p
+}
+
+// index converts an index relative to the oldest point to an index in xs/ys.
+func (b *PlotRingBuffer) index(i int) int {
+	return (b.start + i) % len(b.xs)
+}
+
+// decimate reduces buffer's points visible in the current plot to min and max value per pixel column.
+// x returns x value of i-th point (or nil if buffer's x values should be used).
+// If no decimation is needed, ok is false.
+// It must be called between BeginPlot and EndPlot with the buffer read-locked.
+func (b *PlotRingBuffer) decimate(x func(i int) float64) (xs, ys []float64, ok bool) {
+	width := int(implot.GetPlotSize().X)
+	if width <= 0 || len(b.xs) <= 4*width {
+		return nil, nil, false
+	}
+
+	if x == nil {
+		x = func(i int) float64 {
+			return b.xs[b.index(i)]
+		}
+	}
+
+	limits := implot.GetPlotLimits()
+	xRange := limits.X()
+
+	b.decimatedX, b.decimatedY = decimateMinMax(
+		len(b.xs),
+		x,
+		func(i int) float64 {
+			return b.ys[b.index(i)]
+		},
+		xRange.Min(), xRange.Max(), width,
+		b.decimatedX[:0], b.decimatedY[:0],
+	)
+
+	return b.decimatedX, b.decimatedY, true
+}
+
+// decimateMinMax reduces n points (x(i), y(i)) sorted by x
+// to points with minimum and maximum y in each of width columns of [xMin, xMax] range.
+// Points outside of the range are skipped except the nearest ones, so the line reaches range's edges.
+// Results are appended to dstX and dstY.
+func decimateMinMax(n int, x, y func(i int) float64, xMin, xMax float64, width int, dstX, dstY []float64) (xs, ys []float64) {
+	first := max(sort.Search(n, func(i int) bool { return x(i) >= xMin })-1, 0)
+	last := min(sort.Search(n, func(i int) bool { return x(i) > xMax })+1, n)
+	columnWidth := (xMax - xMin) / float64(width)
+
+	column := func(i int) float64 {
+		return math.Floor((x(i) - xMin) / columnWidth)
+	}
+
+	for i := first; i < last; {
+		col := column(i)
+		minIdx, maxIdx := i, i
+
+		i++
+		for ; i < last && column(i) == col; i++ {
+			if y(i) < y(minIdx) {
+				minIdx = i
+			}
+
+			if y(i) > y(maxIdx) {
+				maxIdx = i
+			}
+		}
+
+		// keep the original order of points
+		if minIdx > maxIdx {
+			minIdx, maxIdx = maxIdx, minIdx
+		}
+
+		dstX, dstY = append(dstX, x(minIdx)), append(dstY, y(minIdx))
+
+		if maxIdx != minIdx {
+			dstX, dstY = append(dstX, x(maxIdx)), append(dstY, y(maxIdx))
+		}
+	}
+
+	return dstX, dstY
+}
@@ -0,0 +1,98 @@
+package giu
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlotRingBuffer_Add(t *testing.T) {
+	b := NewPlotRingBuffer(3)
+
+	b.AddPoints([]float64{1, 2}, []float64{10, 20})
+	assert.Equal(t, 2, b.Len())
+
+	b.Add(3, 30)
+	b.Add(4, 40)
+	assert.Equal(t, 3, b.Len(), "buffer should not grow over its capacity")
+
+	for i, expected := range []float64{2, 3, 4} {
+		x, y := b.At(i)
+		assert.Equal(t, expected, x, "point %d", i)
+		assert.Equal(t, expected*10, y, "point %d", i)
+	}
+
+	b.Clear()
+	assert.Equal(t, 0, b.Len())
+	assert.Equal(t, 3, b.Cap())
+}
+
+func TestPlotRingBuffer_AddConcurrent(t *testing.T) {
+	b := NewPlotRingBuffer(100)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range 50 {
+				b.Add(float64(i), float64(i))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 100, b.Len())
+}
+
+func Test_decimateMinMax(t *testing.T) {
+	values := []float64{0, 5, -5, 1, 2, 3, 9, 4, 8, 7}
+	x := func(i int) float64 { return float64(i) }
+	y := func(i int) float64 { return values[i] }
+
+	tests := []struct {
+		name       string
+		xMin, xMax float64
+		width      int
+		expectedX  []float64
+		expectedY  []float64
+	}{
+		{
+			name:      "one column",
+			xMin:      0,
+			xMax:      10,
+			width:     1,
+			expectedX: []float64{2, 6},
+			expectedY: []float64{-5, 9},
+		},
+		{
+			name:      "two columns",
+			xMin:      0,
+			xMax:      10,
+			width:     2,
+			expectedX: []float64{1, 2, 5, 6},
+			expectedY: []float64{5, -5, 3, 9},
+		},
+		{
+			name:      "visible range keeps neighbours",
+			xMin:      3,
+			xMax:      5,
+			width:     1,
+			expectedX: []float64{2, 3, 4, 5, 6},
+			expectedY: []float64{-5, 1, 2, 3, 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs, ys := decimateMinMax(len(values), x, y, tt.xMin, tt.xMax, tt.width, nil, nil)
+			assert.Equal(t, tt.expectedX, xs)
+			assert.Equal(t, tt.expectedY, ys)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// PlotMarker represents implot.Marker.
+type PlotMarker implot.Marker
+
+// Plot marker shapes.
+const (
+	PlotMarkerNone     PlotMarker = PlotMarker(implot.MarkerNone)
+	PlotMarkerCircle   PlotMarker = PlotMarker(implot.MarkerCircle)
+	PlotMarkerSquare   PlotMarker = PlotMarker(implot.MarkerSquare)
+	PlotMarkerDiamond  PlotMarker = PlotMarker(implot.MarkerDiamond)
+	PlotMarkerUp       PlotMarker = PlotMarker(implot.MarkerUp)
+	PlotMarkerDown     PlotMarker = PlotMarker(implot.MarkerDown)
+	PlotMarkerLeft     PlotMarker = PlotMarker(implot.MarkerLeft)
+	PlotMarkerRight    PlotMarker = PlotMarker(implot.MarkerRight)
+	PlotMarkerCross    PlotMarker = PlotMarker(implot.MarkerCross)
+	PlotMarkerPlus     PlotMarker = PlotMarker(implot.MarkerPlus)
+	PlotMarkerAsterisk PlotMarker = PlotMarker(implot.MarkerAsterisk)
+)
+
+// PlotLocation represents implot.Location.
+type PlotLocation implot.Location
+
+// Plot locations (used by legend).
+const (
+	PlotLocationCenter    PlotLocation = PlotLocation(implot.LocationCenter)
+	PlotLocationNorth     PlotLocation = PlotLocation(implot.LocationNorth)
+	PlotLocationSouth     PlotLocation = PlotLocation(implot.LocationSouth)
+	PlotLocationWest      PlotLocation = PlotLocation(implot.LocationWest)
+	PlotLocationEast      PlotLocation = PlotLocation(implot.LocationEast)
+	PlotLocationNorthWest PlotLocation = PlotLocation(implot.LocationNorthWest)
+	PlotLocationNorthEast PlotLocation = PlotLocation(implot.LocationNorthEast)
+	PlotLocationSouthWest PlotLocation = PlotLocation(implot.LocationSouthWest)
+	PlotLocationSouthEast PlotLocation = PlotLocation(implot.LocationSouthEast)
+)
+
+// plotSeriesStyle holds style of a single plot series.
+// Zero values mean that implot's style is used.
+type plotSeriesStyle struct {
+	color      color.Color
+	lineWeight float32
+	marker     *PlotMarker
+	markerSize float32
+	fillAlpha  float32
+	hidden     *bool
+}
+
+// plotSeries is embedded in plot series to provide setters of their style.
+// self is the series, so that the setters can be chained.
+type plotSeries[P any] struct {
+	self  P
+	style plotSeriesStyle
+}
+
+// Color sets series color.
+func (s *plotSeries[P]) Color(col color.Color) P {
+	s.style.color = col
+	return s.self
+}
+
+// LineWeight sets line weight (in pixels).
+func (s *plotSeries[P]) LineWeight(weight float32) P {
+	s.style.lineWeight = weight
+	return s.self
+}
+
+// Marker sets shape and size of markers drawn at data points (size <= 0 means the default size).
+func (s *plotSeries[P]) Marker(shape PlotMarker, size float32) P {
+	s.style.marker = &shape
+	s.style.markerSize = size
+
+	return s.self
+}
+
+// Fill sets alpha (opacity) of filled areas.
+func (s *plotSeries[P]) Fill(alpha float32) P {
+	s.style.fillAlpha = alpha
+	return s.self
+}
+
+// Hidden binds series visibility to *hidden.
+// The value is updated when the user toggles the series in the legend.
+func (s *plotSeries[P]) Hidden(hidden *bool) P {
+	s.style.hidden = hidden
+	return s.self
+}
+
+// plotHoverSeries is plotSeries of series reporting hovered points.
+type plotHoverSeries[P any] struct {
+	plotSeries[P]
+	onHover func(index int, x, y float64)
+}
+
+// OnHover sets callback called when the plot is hovered.
+// It receives the point nearest to the mouse cursor and its index (counted from Offset).
+func (s *plotHoverSeries[P]) OnHover(onHover func(index int, x, y float64)) P {
+	s.onHover = onHover
+	return s.self
+}
+
+// plotCanvasState remembers visibility and colors of canvas' series (to detect legend toggles and for exporting)
+// and visible ranges of axes in the last frame.
+type plotCanvasState struct {
+	visible   map[string]bool
+	colors    map[string]color.NRGBA
+	ranges    [implot.AxisCOUNT]PlotRange
+	frameSize image.Point
+	recorded  bool
+	// capture receives PNG captured when the plot is rendered next time (see CapturePNG).
+	capture func(data []byte, err error)
+}
+
+// Dispose implements Disposable interface.
+func (s *plotCanvasState) Dispose() {
+	// noop
+}
+
+// currentPlotCanvas is the canvas being built (series are plotted inside of it).
+var currentPlotCanvas *PlotCanvasWidget
+
+// setNext applies the style to the next plot item labeled label.
+// It returns function that must be called after the item is plotted.
+func (s *plotSeriesStyle) setNext(label string) (done func()) {
+	label = Context.PrepareString(label)
+
+	canvas := currentPlotCanvas
+	if canvas != nil && (s.hidden != nil || canvas.onLegendToggle != nil) {
+		s.checkLegendToggle(canvas, label)
+	}
+
+	if s.hidden != nil {
+		implot.HideNextItemV(*s.hidden, implot.CondAlways)
+	}
+
+	if s.color != nil || s.lineWeight > 0 {
+		implot.SetNextLineStyleV(plotToolColor(s.color), s.weight())
+	}
+
+	if s.color != nil || s.fillAlpha > 0 {
+		implot.SetNextFillStyleV(plotToolColor(s.color), s.alpha())
+	}
+
+	if s.marker != nil {
+		implot.SetNextMarkerStyleV(implot.Marker(*s.marker), s.size(), plotAutoColor, -1, plotAutoColor)
+	}
+
+	return func() {
+		if canvas == nil {
+			return
+		}
+
+		if item := implot.GetItem(label); item.CData != nil {
+			state := canvas.getState()
+			state.visible[label] = item.Show()
+			state.colors[label] = itemColor(item.Color())
+		}
+	}
+}
+
+// checkLegendToggle compares item's visibility with the one remembered in the previous frame.
+// As implot toggles items in EndPlot, the difference means that the user clicked the legend entry.
+func (s *plotSeriesStyle) checkLegendToggle(canvas *PlotCanvasWidget, label string) {
+	item := implot.GetItem(label)
+	if item.CData == nil {
+		return
+	}
+
+	visible := item.Show()
+	if prev, ok := canvas.getState().visible[label]; !ok || prev == visible {
+		return
+	}
+
+	if s.hidden != nil {
+		*s.hidden = !visible
+	}
+
+	if canvas.onLegendToggle != nil {
+		canvas.onLegendToggle(label, visible)
+	}
+}
+
+// implot uses -1 for "auto" values.
+func (s *plotSeriesStyle) weight() float32 {
+	if s.lineWeight > 0 {
+		return s.lineWeight
+	}
+
+	return -1
+}
+
+func (s *plotSeriesStyle) alpha() float32 {
+	if s.fillAlpha > 0 {
+		return s.fillAlpha
+	}
+
+	return -1
+}
+
+func (s *plotSeriesStyle) size() float32 {
+	if s.markerSize > 0 {
+		return s.markerSize
+	}
+
+	return -1
+}
@@ -0,0 +1,102 @@
+package giu
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// TimeToPlotValue converts t to a value of the time axis (Unix time in seconds).
+func TimeToPlotValue(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// PlotValueToTime converts a value of the time axis back to time.Time.
+func PlotValueToTime(v float64) time.Time {
+	return time.Unix(0, int64(v*float64(time.Second)))
+}
+
+// timesToPlotValues converts times to values of the time axis.
+func timesToPlotValues(times []time.Time) []float64 {
+	result := make([]float64, len(times))
+	for i, t := range times {
+		result[i] = TimeToPlotValue(t)
+	}
+
+	return result
+}
+
+// LineTime adds a line of (times[i], values[i]) points to the canvas.
+// Use it together with PlotCanvasWidget.TimeAxis.
+func LineTime(title string, times []time.Time, values []float64) *LineXYPlot {
+	return LineXY(title, timesToPlotValues(times), values)
+}
+
+// ScatterTime adds a scatter plot of (times[i], values[i]) points to the canvas.
+// Use it together with PlotCanvasWidget.TimeAxis.
+func ScatterTime(label string, times []time.Time, values []float64) *ScatterXYPlot {
+	return ScatterXY(label, timesToPlotValues(times), values)
+}
+
+// plotTimeFormat describes how implot formats labels of the time axis.
+type plotTimeFormat struct {
+	local   bool
+	iso8601 bool
+	clock24 bool
+}
+
+// timeFormatForLocale returns date and clock conventions for POSIX locale name (e.g. en_US.UTF-8).
+// US locales use month/day dates and 12-hour clock, others use ISO 8601 dates and 24-hour clock.
+func timeFormatForLocale(locale string) (iso8601, clock24 bool) {
+	// strip encoding and modifier
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+
+	if strings.HasSuffix(locale, "_US") {
+		return false, false
+	}
+
+	return true, true
+}
+
+// systemTimeFormat returns date and clock conventions of the system locale.
+var systemTimeFormat = sync.OnceValues(func() (iso8601, clock24 bool) {
+	return timeFormatForLocale(systemLocale())
+})
+
+// systemLocale returns locale name used for formatting time (according to POSIX environment variables).
+func systemLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if locale := os.Getenv(env); locale != "" {
+			return locale
+		}
+	}
+
+	return ""
+}
+
+// push applies the format to implot's style. It returns function restoring the previous one.
+func (f plotTimeFormat) push() (pop func()) {
+	style := implot.GetStyle()
+	prev := plotTimeFormat{
+		local:   style.UseLocalTime(),
+		iso8601: style.UseISO8601(),
+		clock24: style.Use24HourClock(),
+	}
+
+	f.apply(style)
+
+	return func() {
+		prev.apply(style)
+	}
+}
+
+func (f plotTimeFormat) apply(style *implot.Style) {
+	style.SetUseLocalTime(f.local)
+	style.SetUseISO8601(f.iso8601)
+	style.SetUse24HourClock(f.clock24)
+}
@@ -0,0 +1,37 @@
+package giu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeToPlotValue(t *testing.T) {
+	tm := time.Date(2024, 2, 29, 13, 45, 30, 500_000_000, time.UTC)
+
+	assert.InDelta(t, 1709214330.5, TimeToPlotValue(tm), 1e-6)
+	assert.True(t, tm.Equal(PlotValueToTime(TimeToPlotValue(tm))))
+}
+
+func Test_timeFormatForLocale(t *testing.T) {
+	tests := []struct {
+		locale           string
+		iso8601, clock24 bool
+	}{
+		{locale: "en_US.UTF-8", iso8601: false, clock24: false},
+		{locale: "es_US", iso8601: false, clock24: false},
+		{locale: "de_DE.UTF-8@euro", iso8601: true, clock24: true},
+		{locale: "en_GB", iso8601: true, clock24: true},
+		{locale: "C", iso8601: true, clock24: true},
+		{locale: "", iso8601: true, clock24: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			iso8601, clock24 := timeFormatForLocale(tt.locale)
+			assert.Equal(t, tt.iso8601, iso8601)
+			assert.Equal(t, tt.clock24, clock24)
+		})
+	}
+}
@@ -0,0 +1,451 @@
+package giu
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// PlotRect represents an area of the plot in plot coordinates.
+type PlotRect struct {
+	XMin, XMax float64
+	YMin, YMax float64
+}
+
+// plotAutoColor tells implot to use the next color of the current colormap (IMPLOT_AUTO_COL).
+var plotAutoColor = imgui.Vec4{X: 0, Y: 0, Z: 0, W: -1}
+
+// plotToolColor returns col or plotAutoColor if col is nil.
+func plotToolColor(col color.Color) imgui.Vec4 {
+	if col == nil {
+		return plotAutoColor
+	}
+
+	return ToVec4Color(col)
+}
+
+// plotLabelColor returns col or a transparent color (meaning no background) if col is nil.
+func plotLabelColor(col color.Color) imgui.Vec4 {
+	if col == nil {
+		return imgui.Vec4{}
+	}
+
+	return ToVec4Color(col)
+}
+
+// plotToolID converts giu ID to the integer id of implot's drag tool.
+func plotToolID(id ID) int32 {
+	return int32(imgui.IDStr(id.String()))
+}
+
+// escapePlotFormat escapes text so that it can be passed to implot as a format string.
+func escapePlotFormat(text string) string {
+	return strings.ReplaceAll(Context.PrepareString(text), "%", "%%")
+}
+
+// plotHover calls onHover with the point nearest (in pixels) to the mouse cursor
+// if the plot is hovered. x and y return coordinates of i-th of n points.
+// It must be called after plotting the series, so it uses the same axes.
+func plotHover(onHover func(index int, x, y float64), n int, x, y func(i int) float64) {
+	if onHover == nil || n == 0 || !implot.IsPlotHovered() {
+		return
+	}
+
+	mouse := implot.GetPlotMousePos()
+	limits := implot.GetPlotLimits()
+	xRange, yRange := limits.X(), limits.Y()
+	size := implot.GetPlotSize()
+
+	i := nearestPoint(
+		n, x, y,
+		mouse.X, mouse.Y,
+		float64(size.X)/(xRange.Max()-xRange.Min()),
+		float64(size.Y)/(yRange.Max()-yRange.Min()),
+	)
+
+	onHover(i, x(i), y(i))
+}
+
+// plotHoverXY is plotHover for points (xs[i], ys[i]) plotted with offset.
+func plotHoverXY[T PlotNumber](onHover func(index int, x, y float64), xs, ys []T, offset int) {
+	n := min(len(xs), len(ys))
+
+	plotHover(onHover, n, func(i int) float64 {
+		return float64(xs[(offset+i)%n])
+	}, func(i int) float64 {
+		return float64(ys[(offset+i)%n])
+	})
+}
+
+// nearestPoint returns index of the point (x(i), y(i)) nearest to (px, py).
+// scaleX and scaleY convert distances along axes to pixels.
+func nearestPoint(n int, x, y func(i int) float64, px, py, scaleX, scaleY float64) int {
+	result, minDist := 0, math.Inf(1)
+
+	for i := range n {
+		dx, dy := (x(i)-px)*scaleX, (y(i)-py)*scaleY
+		if dist := dx*dx + dy*dy; dist < minDist {
+			result, minDist = i, dist
+		}
+	}
+
+	return result
+}
+
+// DragLineXPlot is a vertical line that can be dragged with mouse.
+type DragLineXPlot struct {
+	id        ID
+	value     *float64
+	color     color.Color
+	thickness float32
+	flags     PlotDragToolFlags
+	onChange  func()
+}
+
+// DragLineX adds a draggable vertical line at x = *value to the canvas.
+func DragLineX(value *float64) *DragLineXPlot {
+	return &DragLineXPlot{
+		id:        GenAutoID("DragLineX"),
+		value:     value,
+		thickness: 1,
+	}
+}
+
+// ID sets the internal id of the line.
+func (p *DragLineXPlot) ID(id ID) *DragLineXPlot {
+	p.id = id
+	return p
+}
+
+// Color sets line color (by default the next color of the colormap is used).
+func (p *DragLineXPlot) Color(col color.Color) *DragLineXPlot {
+	p.color = col
+	return p
+}
+
+// Thickness sets line thickness.
+func (p *DragLineXPlot) Thickness(thickness float32) *DragLineXPlot {
+	p.thickness = thickness
+	return p
+}
+
+// Flags sets drag tool flags.
+func (p *DragLineXPlot) Flags(flags PlotDragToolFlags) *DragLineXPlot {
+	p.flags = flags
+	return p
+}
+
+// OnChange sets callback called when the line is dragged.
+func (p *DragLineXPlot) OnChange(onChange func()) *DragLineXPlot {
+	p.onChange = onChange
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *DragLineXPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	if implot.DragLineXV(
+		plotToolID(p.id),
+		p.value,
+		plotToolColor(p.color),
+		p.thickness,
+		implot.DragToolFlags(p.flags),
+		nil, nil, nil,
+	) && p.onChange != nil {
+		p.onChange()
+	}
+}
+
+// DragLineYPlot is a horizontal line that can be dragged with mouse.
+type DragLineYPlot struct {
+	id        ID
+	value     *float64
+	color     color.Color
+	thickness float32
+	flags     PlotDragToolFlags
+	onChange  func()
+}
+
+// DragLineY adds a draggable horizontal line at y = *value to the canvas.
+func DragLineY(value *float64) *DragLineYPlot {
+	return &DragLineYPlot{
+		id:        GenAutoID("DragLineY"),
+		value:     value,
+		thickness: 1,
+	}
+}
+
+// ID sets the internal id of the line.
+func (p *DragLineYPlot) ID(id ID) *DragLineYPlot {
+	p.id = id
+	return p
+}
+
+// Color sets line color (by default the next color of the colormap is used).
+func (p *DragLineYPlot) Color(col color.Color) *DragLineYPlot {
+	p.color = col
+	return p
+}
+
+// Thickness sets line thickness.
+func (p *DragLineYPlot) Thickness(thickness float32) *DragLineYPlot {
+	p.thickness = thickness
+	return p
+}
+
+// Flags sets drag tool flags.
+func (p *DragLineYPlot) Flags(flags PlotDragToolFlags) *DragLineYPlot {
+	p.flags = flags
+	return p
+}
+
+// OnChange sets callback called when the line is dragged.
+func (p *DragLineYPlot) OnChange(onChange func()) *DragLineYPlot {
+	p.onChange = onChange
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *DragLineYPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	if implot.DragLineYV(
+		plotToolID(p.id),
+		p.value,
+		plotToolColor(p.color),
+		p.thickness,
+		implot.DragToolFlags(p.flags),
+		nil, nil, nil,
+	) && p.onChange != nil {
+		p.onChange()
+	}
+}
+
+// DragPointPlot is a point that can be dragged with mouse.
+type DragPointPlot struct {
+	id       ID
+	x, y     *float64
+	color    color.Color
+	size     float32
+	flags    PlotDragToolFlags
+	onChange func()
+}
+
+// DragPoint adds a draggable point at (*x, *y) to the canvas.
+func DragPoint(x, y *float64) *DragPointPlot {
+	return &DragPointPlot{
+		id:   GenAutoID("DragPoint"),
+		x:    x,
+		y:    y,
+		size: 4,
+	}
+}
+
+// ID sets the internal id of the point.
+func (p *DragPointPlot) ID(id ID) *DragPointPlot {
+	p.id = id
+	return p
+}
+
+// Color sets point color (by default the next color of the colormap is used).
+func (p *DragPointPlot) Color(col color.Color) *DragPointPlot {
+	p.color = col
+	return p
+}
+
+// Size sets point's radius.
+func (p *DragPointPlot) Size(size float32) *DragPointPlot {
+	p.size = size
+	return p
+}
+
+// Flags sets drag tool flags.
+func (p *DragPointPlot) Flags(flags PlotDragToolFlags) *DragPointPlot {
+	p.flags = flags
+	return p
+}
+
+// OnChange sets callback called when the point is dragged.
+func (p *DragPointPlot) OnChange(onChange func()) *DragPointPlot {
+	p.onChange = onChange
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *DragPointPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	if implot.DragPointV(
+		plotToolID(p.id),
+		p.x, p.y,
+		plotToolColor(p.color),
+		p.size,
+		implot.DragToolFlags(p.flags),
+		nil, nil, nil,
+	) && p.onChange != nil {
+		p.onChange()
+	}
+}
+
+// DragRectPlot is a rectangle that can be dragged and resized with mouse.
+type DragRectPlot struct {
+	id             ID
+	x1, y1, x2, y2 *float64
+	color          color.Color
+	flags          PlotDragToolFlags
+	onChange       func()
+}
+
+// DragRect adds a draggable rectangle with corners (*x1, *y1) and (*x2, *y2) to the canvas.
+func DragRect(x1, y1, x2, y2 *float64) *DragRectPlot {
+	return &DragRectPlot{
+		id: GenAutoID("DragRect"),
+		x1: x1,
+		y1: y1,
+		x2: x2,
+		y2: y2,
+	}
+}
+
+// ID sets the internal id of the rectangle.
+func (p *DragRectPlot) ID(id ID) *DragRectPlot {
+	p.id = id
+	return p
+}
+
+// Color sets rectangle color (by default the next color of the colormap is used).
+func (p *DragRectPlot) Color(col color.Color) *DragRectPlot {
+	p.color = col
+	return p
+}
+
+// Flags sets drag tool flags.
+func (p *DragRectPlot) Flags(flags PlotDragToolFlags) *DragRectPlot {
+	p.flags = flags
+	return p
+}
+
+// OnChange sets callback called when the rectangle is dragged or resized.
+func (p *DragRectPlot) OnChange(onChange func()) *DragRectPlot {
+	p.onChange = onChange
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *DragRectPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	if implot.DragRectV(
+		plotToolID(p.id),
+		p.x1, p.y1, p.x2, p.y2,
+		plotToolColor(p.color),
+		implot.DragToolFlags(p.flags),
+		nil, nil, nil,
+	) && p.onChange != nil {
+		p.onChange()
+	}
+}
+
+// TagPlot is a label drawn on an axis at given position.
+type TagPlot struct {
+	value    float64
+	text     string
+	vertical bool
+	color    color.Color
+}
+
+// TagX adds a tag to the x axis at x = value.
+// If text is empty, value is shown.
+func TagX(value float64, text string) *TagPlot {
+	return &TagPlot{
+		value:    value,
+		text:     text,
+		vertical: true,
+	}
+}
+
+// TagY adds a tag to the y axis at y = value.
+// If text is empty, value is shown.
+func TagY(value float64, text string) *TagPlot {
+	return &TagPlot{
+		value: value,
+		text:  text,
+	}
+}
+
+// Color sets tag's background color.
+func (p *TagPlot) Color(col color.Color) *TagPlot {
+	p.color = col
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *TagPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	col := plotLabelColor(p.color)
+
+	switch {
+	case p.vertical && p.text == "":
+		implot.TagXBool(p.value, col)
+	case p.vertical:
+		implot.TagXStr(p.value, col, escapePlotFormat(p.text))
+	case p.text == "":
+		implot.TagYBool(p.value, col)
+	default:
+		implot.TagYStr(p.value, col, escapePlotFormat(p.text))
+	}
+}
+
+// AnnotationPlot is a label pointing to a position of the plot.
+type AnnotationPlot struct {
+	x, y             float64
+	text             string
+	color            color.Color
+	offsetX, offsetY float32
+	clamp            bool
+}
+
+// Annotation adds a label at (x, y) to the canvas.
+func Annotation(x, y float64, text string) *AnnotationPlot {
+	return &AnnotationPlot{
+		x:    x,
+		y:    y,
+		text: text,
+	}
+}
+
+// Color sets annotation's background color.
+func (p *AnnotationPlot) Color(col color.Color) *AnnotationPlot {
+	p.color = col
+	return p
+}
+
+// PixelOffset moves the label by (x, y) pixels from the point it annotates.
+func (p *AnnotationPlot) PixelOffset(x, y float32) *AnnotationPlot {
+	p.offsetX, p.offsetY = x, y
+	return p
+}
+
+// Clamp keeps the label inside of the plot area.
+func (p *AnnotationPlot) Clamp(clamp bool) *AnnotationPlot {
+	p.clamp = clamp
+	return p
+}
+
+// Plot implements Plot interface.
+func (p *AnnotationPlot) Plot() {
+	setPlotYAxis(ImPlotYAxisLeft)
+
+	implot.AnnotationStr(
+		p.x, p.y,
+		plotLabelColor(p.color),
+		imgui.Vec2{X: p.offsetX, Y: p.offsetY},
+		p.clamp,
+		escapePlotFormat(p.text),
+	)
+}
@@ -0,0 +1,44 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_nearestPoint(t *testing.T) {
+	xs := []float64{0, 1, 2, 3}
+	ys := []float64{0, 100, 0, 100}
+	x := func(i int) float64 { return xs[i] }
+	y := func(i int) float64 { return ys[i] }
+
+	tests := []struct {
+		name           string
+		px, py         float64
+		scaleX, scaleY float64
+		expected       int
+	}{
+		{
+			name:     "same scale",
+			px:       2.9,
+			py:       1,
+			scaleX:   1,
+			scaleY:   1,
+			expected: 2,
+		},
+		{
+			name:     "distance is measured in pixels",
+			px:       2.9,
+			py:       1,
+			scaleX:   100,
+			scaleY:   0.01,
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nearestPoint(len(xs), x, y, tt.px, tt.py, tt.scaleX, tt.scaleY))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package giu
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_plotItemAxes(t *testing.T) {
+	type axes struct {
+		x, y PlotAxis
+	}
+
+	tests := []struct {
+		name     string
+		items    []ImPlotYAxis
+		defaultX PlotAxis
+		defaultY PlotAxis
+		expected []axes
+	}{
+		{
+			name:     "default axes",
+			items:    []ImPlotYAxis{ImPlotYAxisLeft},
+			defaultX: AxisX1,
+			defaultY: AxisY1,
+			expected: []axes{{AxisX1, AxisY1}},
+		},
+		{
+			name:     "left axis after right axis",
+			items:    []ImPlotYAxis{ImPlotYAxisFirstOnRight, ImPlotYAxisLeft, ImPlotYAxisSecondOnRight, ImPlotYAxisLeft},
+			defaultX: AxisX1,
+			defaultY: AxisY1,
+			expected: []axes{{AxisX1, AxisY2}, {AxisX1, AxisY1}, {AxisX1, AxisY3}, {AxisX1, AxisY1}},
+		},
+		{
+			name:     "switched axes",
+			items:    []ImPlotYAxis{ImPlotYAxisFirstOnRight, ImPlotYAxisLeft},
+			defaultX: AxisX2,
+			defaultY: AxisY3,
+			expected: []axes{{AxisX2, AxisY2}, {AxisX2, AxisY3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := make([]axes, len(tt.items))
+			for i, item := range tt.items {
+				result[i].x, result[i].y = plotItemAxes(item, tt.defaultX, tt.defaultY)
+			}
+
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// Test_plotItemsSetAxes checks that every plot item sets its axes, as axes set by an item
+// would be used by the following items otherwise.
+func Test_plotItemsSetAxes(t *testing.T) {
+	for _, file := range []string{"Plot.go", "PlotFinance.go", "PlotTools.go"} {
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "Plot" {
+				continue
+			}
+
+			setsAxes := false
+
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "setPlotYAxis" {
+						setsAxes = true
+					}
+				}
+
+				return !setsAxes
+			})
+
+			assert.True(t, setsAxes, "%s: %s.Plot should call setPlotYAxis", file, types.ExprString(fn.Recv.List[0].Type))
+		}
+	}
+}
+
+func Test_heatmapValid(t *testing.T) {
+	assert.True(t, heatmapValid([]float64{1, 2, 3, 4, 5, 6}, 2, 3))
+	assert.False(t, heatmapValid([]float64{1, 2, 3}, 2, 3), "short values shouldn't be plotted")
+	assert.False(t, heatmapValid(nil, 1, 1), "empty values shouldn't be plotted")
+	assert.False(t, heatmapValid([]float64{1}, 0, 1))
+	assert.False(t, heatmapValid([]float64{1}, -1, -1))
+}
+
+func Test_plotSeriesSetters(t *testing.T) {
+	line := Line("line", nil)
+	assert.Same(t, line, line.Color(nil).LineWeight(2).Marker(PlotMarkerCircle, 0).Fill(0.5).Hidden(nil).OnHover(nil))
+	assert.InDelta(t, 2, line.style.lineWeight, 0)
+	assert.InDelta(t, 0.5, line.style.fillAlpha, 0)
+
+	// setters of all series should return the series they were called on
+	for _, p := range []any{
+		Bar("", nil), BarH("", nil), line, LineXY("", nil, nil), Scatter("", nil), ScatterXY("", nil, nil),
+		Histogram("", nil), Shaded("", nil, nil, nil), ShadedRef("", nil, nil), Stairs("", nil), StairsXY("", nil, nil),
+		Stems("", nil), StemsXY("", nil, nil), Digital("", nil, nil), InfLines("", nil),
+	} {
+		result := reflect.ValueOf(p).MethodByName("Fill").Call([]reflect.Value{reflect.ValueOf(float32(0))})
+		assert.Same(t, p, result[0].Interface(), "%T", p)
+	}
+}
@@ -0,0 +1,393 @@
+package giu
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// textFlow places inline items one after another,
+// wrapping them at the end of the available region.
+type textFlow struct {
+	wrapEnd   float32
+	lineStart bool
+}
+
+// newTextFlow creates a textFlow wrapping at the end of the current available region.
+func newTextFlow() *textFlow {
+	availableW, _ := GetAvailableRegion()
+
+	return &textFlow{
+		wrapEnd:   imgui.CursorScreenPos().X + availableW,
+		lineStart: true,
+	}
+}
+
+// next puts the next item of width w in the current line if it fits.
+// Otherwise the item will be placed in the next line.
+func (f *textFlow) next(w float32) {
+	if !f.lineStart && imgui.ItemRectMax().X+w <= f.wrapEnd {
+		imgui.SameLineV(0, 0)
+	}
+
+	f.lineStart = false
+}
+
+// lineBreak forces the next item to be placed in a new line.
+func (f *textFlow) lineBreak() {
+	if f.lineStart {
+		imgui.NewLine()
+	}
+
+	f.lineStart = true
+}
+
+// splitWords splits text into words keeping trailing spaces.
+func splitWords(s string) []string {
+	var result []string
+
+	for s != "" {
+		end := strings.IndexByte(s, ' ')
+		if end < 0 {
+			result = append(result, s)
+			break
+		}
+
+		// keep all following spaces with the word
+		for end < len(s) && s[end] == ' ' {
+			end++
+		}
+
+		result = append(result, s[:end])
+		s = s[end:]
+	}
+
+	return result
+}
+
+// textIndexAt returns byte index of the character boundary in text
+// that is the nearest to x (relative to the beginning of the text).
+func textIndexAt(text string, x float32) int {
+	var prev float32
+
+	for i, r := range text {
+		w, _ := CalcTextSize(text[:i+utf8.RuneLen(r)])
+		if x < (prev+w)/2 {
+			return i
+		}
+
+		prev = w
+	}
+
+	return len(text)
+}
+
+// RichTextSpan is a piece of RichTextWidget's text rendered with the same style.
+type RichTextSpan struct {
+	text       string
+	color      color.Color
+	background color.Color
+	font       *FontInfo
+	fontStyle  FontStyle
+	underline  bool
+	strike     bool
+	onClick    func()
+	tooltip    string
+}
+
+// Span creates a new RichTextSpan.
+func Span(text string) *RichTextSpan {
+	return &RichTextSpan{
+		text: Context.PrepareString(text),
+	}
+}
+
+// Spanf is a formatting version of Span.
+func Spanf(format string, args ...any) *RichTextSpan {
+	return Span(fmt.Sprintf(format, args...))
+}
+
+// Color sets text color.
+func (s *RichTextSpan) Color(col color.Color) *RichTextSpan {
+	s.color = col
+	return s
+}
+
+// Background sets color of the highlight drawn behind the text.
+func (s *RichTextSpan) Background(col color.Color) *RichTextSpan {
+	s.background = col
+	return s
+}
+
+// Font sets specific font (does like Style().SetFont).
+func (s *RichTextSpan) Font(font *FontInfo) *RichTextSpan {
+	s.font = font
+	return s
+}
+
+// Bold uses bold variant of the font family (see FontAtlas.AddFontFamilyFS).
+func (s *RichTextSpan) Bold() *RichTextSpan {
+	s.fontStyle |= FontStyleBold
+	return s
+}
+
+// Italic uses italic variant of the font family (see FontAtlas.AddFontFamilyFS).
+func (s *RichTextSpan) Italic() *RichTextSpan {
+	s.fontStyle |= FontStyleItalic
+	return s
+}
+
+// Underline draws a line under the text.
+func (s *RichTextSpan) Underline() *RichTextSpan {
+	s.underline = true
+	return s
+}
+
+// Strikethrough draws a line through the text.
+func (s *RichTextSpan) Strikethrough() *RichTextSpan {
+	s.strike = true
+	return s
+}
+
+// OnClick makes the span a link. onClick is called when the span is clicked.
+// Unless Color is set, link color of the current style is used.
+func (s *RichTextSpan) OnClick(onClick func()) *RichTextSpan {
+	s.onClick = onClick
+	return s
+}
+
+// Tooltip sets a tooltip shown when the span is hovered.
+func (s *RichTextSpan) Tooltip(tip string) *RichTextSpan {
+	s.tooltip = Context.PrepareString(tip)
+	return s
+}
+
+// textColor returns color that the span should be rendered with (or nil if the default one should be used).
+func (s *RichTextSpan) textColor() color.Color {
+	if s.color == nil && s.onClick != nil {
+		return Vec4ToRGBA(*imgui.StyleColorVec4(imgui.ColTextLink))
+	}
+
+	return s.color
+}
+
+var _ Disposable = &richTextState{}
+
+type richTextState struct {
+	// anchor and cursor are byte offsets of the selection ends in the whole text.
+	anchor, cursor int
+	selecting      bool
+	// active is true if the widget was clicked last, so it receives copy shortcut.
+	active bool
+}
+
+// Dispose implements Disposable interface.
+func (s *richTextState) Dispose() {
+	// noop
+}
+
+func (s *richTextState) selection() (start, end int) {
+	return min(s.anchor, s.cursor), max(s.anchor, s.cursor)
+}
+
+// clamp keeps the selection within text of length bytes (text of spans could get shorter).
+func (s *richTextState) clamp(length int) {
+	s.anchor = max(0, min(s.anchor, length))
+	s.cursor = max(0, min(s.cursor, length))
+}
+
+var _ Widget = &RichTextWidget{}
+
+// RichTextWidget is a text consisting of differently styled spans.
+// It wraps words of all spans within the available width.
+// Text could be selected with mouse and copied with Ctrl+C or context menu.
+type RichTextWidget struct {
+	id    ID
+	spans []*RichTextSpan
+}
+
+// RichText creates a new RichTextWidget.
+func RichText(spans ...*RichTextSpan) *RichTextWidget {
+	return &RichTextWidget{
+		id:    GenAutoID("RichText"),
+		spans: spans,
+	}
+}
+
+// ID sets the internal id of rich text widget.
+func (r *RichTextWidget) ID(id ID) *RichTextWidget {
+	r.id = id
+	return r
+}
+
+func (r *RichTextWidget) getState() *richTextState {
+	if state := GetState[richTextState](Context, r.id); state != nil {
+		return state
+	}
+
+	newState := &richTextState{}
+	SetState(Context, r.id, newState)
+
+	return newState
+}
+
+// text returns the whole text of the widget.
+func (r *RichTextWidget) text() string {
+	var result strings.Builder
+
+	for _, span := range r.spans {
+		result.WriteString(span.text)
+	}
+
+	return result.String()
+}
+
+// Build implements Widget interface.
+func (r *RichTextWidget) Build() {
+	state := r.getState()
+	selStart, selEnd := state.selection()
+	flow := newTextFlow()
+	hovered := -1
+	offset := 0
+
+	for _, span := range r.spans {
+		isFontPushed := PushFont(Context.FontAtlas.resolveFont(span.font, "", span.fontStyle))
+
+		if col := span.textColor(); col != nil {
+			PushStyleColor(StyleColorText, col)
+		}
+
+		for _, word := range splitWords(span.text) {
+			w, _ := CalcTextSize(word)
+			flow.next(w)
+
+			pos := imgui.CursorScreenPos()
+
+			r.drawBackground(span, word, pos, max(selStart-offset, 0), min(selEnd-offset, len(word)))
+
+			imgui.TextUnformatted(word)
+
+			if imgui.IsItemHovered() {
+				hovered = offset + textIndexAt(word, imgui.MousePos().X-pos.X)
+			}
+
+			r.decorate(span)
+
+			offset += len(word)
+		}
+
+		if span.textColor() != nil {
+			PopStyleColor()
+		}
+
+		if isFontPushed {
+			PopFont()
+		}
+	}
+
+	r.handleSelection(state, hovered)
+}
+
+// drawBackground draws span's background and selection of word[selStart:selEnd] (if any).
+func (r *RichTextWidget) drawBackground(span *RichTextSpan, word string, pos imgui.Vec2, selStart, selEnd int) {
+	drawList := imgui.WindowDrawList()
+	w, h := CalcTextSize(word)
+
+	if span.background != nil {
+		drawList.AddRectFilled(pos, imgui.Vec2{X: pos.X + w, Y: pos.Y + h}, ColorToUint(span.background))
+	}
+
+	if selStart < selEnd {
+		x0, _ := CalcTextSize(word[:selStart])
+		x1, _ := CalcTextSize(word[:selEnd])
+		drawList.AddRectFilled(
+			imgui.Vec2{X: pos.X + x0, Y: pos.Y},
+			imgui.Vec2{X: pos.X + x1, Y: pos.Y + h},
+			imgui.ColorU32Col(imgui.ColTextSelectedBg),
+		)
+	}
+}
+
+// decorate draws lines over the last rendered word and handles its events.
+func (r *RichTextWidget) decorate(span *RichTextSpan) {
+	drawList := imgui.WindowDrawList()
+	rectMin, rectMax := imgui.ItemRectMin(), imgui.ItemRectMax()
+	col := imgui.ColorU32Col(imgui.ColText)
+	hovered := imgui.IsItemHovered()
+
+	if span.strike {
+		y := (rectMin.Y + rectMax.Y) / 2
+		drawList.AddLine(imgui.Vec2{X: rectMin.X, Y: y}, imgui.Vec2{X: rectMax.X, Y: y}, col)
+	}
+
+	if span.underline || (span.onClick != nil && hovered) {
+		drawList.AddLine(imgui.Vec2{X: rectMin.X, Y: rectMax.Y}, imgui.Vec2{X: rectMax.X, Y: rectMax.Y}, col)
+	}
+
+	if !hovered {
+		return
+	}
+
+	if span.tooltip != "" && imgui.BeginItemTooltip() {
+		imgui.TextUnformatted(span.tooltip)
+		imgui.EndTooltip()
+	}
+
+	if span.onClick != nil {
+		imgui.SetMouseCursor(imgui.MouseCursorHand)
+
+		if imgui.IsItemClicked() {
+			span.onClick()
+		}
+	}
+}
+
+// handleSelection updates selection and handles copying.
+// hovered is an offset of the character under mouse cursor (or -1).
+func (r *RichTextWidget) handleSelection(state *richTextState, hovered int) {
+	popupID := r.id.String() + "##contextMenu"
+	text := r.text()
+
+	state.clamp(len(text))
+
+	switch {
+	case IsMouseClicked(MouseButtonLeft) && hovered >= 0:
+		state.anchor, state.cursor = hovered, hovered
+		state.selecting = true
+		state.active = true
+	case IsMouseClicked(MouseButtonLeft) && !imgui.IsPopupOpenStr(popupID):
+		state.anchor, state.cursor = 0, 0
+		state.active = false
+	case state.selecting && IsMouseDown(MouseButtonLeft) && hovered >= 0:
+		state.cursor = hovered
+	case IsMouseReleased(MouseButtonLeft):
+		state.selecting = false
+	}
+
+	if IsMouseClicked(MouseButtonRight) && hovered >= 0 {
+		state.active = true
+
+		imgui.OpenPopupStr(popupID)
+	}
+
+	selStart, selEnd := state.selection()
+
+	if imgui.BeginPopup(popupID) {
+		if imgui.MenuItemBoolV("Copy", "Ctrl+C", false, selStart < selEnd) {
+			imgui.SetClipboardText(text[selStart:selEnd])
+		}
+
+		if imgui.MenuItemBool("Select all") {
+			state.anchor, state.cursor = 0, len(text)
+		}
+
+		imgui.EndPopup()
+	}
+
+	if state.active && selStart < selEnd && imgui.IsKeyChordPressed(imgui.KeyChord(imgui.ModCtrl)|imgui.KeyChord(imgui.KeyC)) {
+		imgui.SetClipboardText(text[selStart:selEnd])
+	}
+}
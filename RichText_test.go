@@ -0,0 +1,42 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_splitWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"single word", "word", []string{"word"}},
+		{"keeps trailing spaces", "hello  world ", []string{"hello  ", "world "}},
+		{"leading spaces", " hello", []string{" ", "hello"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, splitWords(test.source))
+		})
+	}
+}
+
+func Test_richTextState_clamp(t *testing.T) {
+	state := &richTextState{anchor: 2, cursor: 12}
+	text := "short"
+
+	state.clamp(len(text))
+
+	start, end := state.selection()
+	assert.Equal(t, "ort", text[start:end], "selection should be clamped when text gets shorter")
+
+	state.anchor, state.cursor = 20, 15
+	state.clamp(len(text))
+
+	start, end = state.selection()
+	assert.Empty(t, text[start:end])
+}
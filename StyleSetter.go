@@ -17,6 +17,8 @@ type StyleSetter struct {
 	plotColors map[StylePlotColorID]color.Color
 	plotStyles map[StylePlotVarID]any
 	font       *FontInfo
+	fontFamily string
+	fontStyle  FontStyle
 	fontSize   float32
 	disabled   bool
 
@@ -43,6 +45,7 @@ func Style() *StyleSetter {
 // Add puts other "on top" of ss, meaning, "other" is applied after "ss".
 // e.g. if both StyleSetters set imgui.StyleVarAlpha, the value from "other" will be used.
 // NOTE: font value "nil" is treated as "not set" and will not be changed if declared by other.
+// NOTE: font styles (bold/italic) are combined.
 // NOTE: true is preffered over false for disabled field.
 // NOTE: layout field will be reset.
 func (ss *StyleSetter) Add(other *StyleSetter) *StyleSetter {
@@ -70,6 +73,12 @@ func (ss *StyleSetter) Add(other *StyleSetter) *StyleSetter {
 		ss.font = other.font
 	}
 
+	if other.fontFamily != "" {
+		ss.fontFamily = other.fontFamily
+	}
+
+	ss.fontStyle |= other.fontStyle
+
 	if other.disabled {
 		ss.disabled = true
 	}
@@ -183,6 +192,24 @@ func (ss *StyleSetter) SetFont(font *FontInfo) *StyleSetter {
 	return ss
 }
 
+// FontFamily sets font family (see FontAtlas.AddFontFamilyFS).
+func (ss *StyleSetter) FontFamily(family string) *StyleSetter {
+	ss.fontFamily = family
+	return ss
+}
+
+// Bold uses bold variant of the font family.
+func (ss *StyleSetter) Bold() *StyleSetter {
+	ss.fontStyle |= FontStyleBold
+	return ss
+}
+
+// Italic uses italic variant of the font family.
+func (ss *StyleSetter) Italic() *StyleSetter {
+	ss.fontStyle |= FontStyleItalic
+	return ss
+}
+
 // SetFontSize sets size of the font.
 // NOTE: Be aware, that StyleSetter needs to add a new font to font atlas for
 // each font's size.
@@ -319,8 +346,8 @@ func (ss *StyleSetter) Push() {
 	}
 
 	// push font
-	if ss.font != nil {
-		ss.isFontPushed = PushFont(ss.font)
+	if font := Context.FontAtlas.resolveFont(ss.font, ss.fontFamily, ss.fontStyle); font != nil {
+		ss.isFontPushed = PushFont(font)
 	}
 
 	if ss.fontSize != 0 {
@@ -0,0 +1,128 @@
+package giu
+
+import (
+	"image"
+
+	"github.com/AllenDang/cimgui-go/implot"
+)
+
+// PlotRange is a range of an axis which can be shared between several PlotCanvasWidgets.
+// Linked axes (see PlotCanvasWidget.LinkXAxis) are panned and zoomed together.
+// Min and Max are updated as user interacts with any of the linked plots.
+type PlotRange struct {
+	Min, Max float64
+}
+
+// NewPlotRange creates a new PlotRange.
+func NewPlotRange(rangeMin, rangeMax float64) *PlotRange {
+	return &PlotRange{
+		Min: rangeMin,
+		Max: rangeMax,
+	}
+}
+
+var _ Widget = &SubplotsWidget{}
+
+// SubplotsWidget arranges several plots in a grid.
+// Plots may have linked axes and could be resized with mouse.
+type SubplotsWidget struct {
+	id                   ID
+	title                string
+	rows, cols           int
+	width, height        int
+	flags                PlotSubplotFlags
+	rowRatios, colRatios []float32
+	plots                []*PlotCanvasWidget
+}
+
+// Subplots creates a new SubplotsWidget with rows x cols grid of plots.
+func Subplots(rows, cols int) *SubplotsWidget {
+	return &SubplotsWidget{
+		id:     GenAutoID("Subplots"),
+		rows:   rows,
+		cols:   cols,
+		width:  -1,
+		height: 0,
+	}
+}
+
+// ID sets the internal id of subplots.
+func (s *SubplotsWidget) ID(id ID) *SubplotsWidget {
+	s.id = id
+	return s
+}
+
+// Title sets the title displayed above subplots.
+func (s *SubplotsWidget) Title(title string) *SubplotsWidget {
+	s.title = title
+	return s
+}
+
+// Size sets size of the whole grid.
+func (s *SubplotsWidget) Size(width, height int) *SubplotsWidget {
+	s.width, s.height = width, height
+	return s
+}
+
+// Flags sets subplot flags (e.g. linking axes).
+func (s *SubplotsWidget) Flags(flags PlotSubplotFlags) *SubplotsWidget {
+	s.flags = flags
+	return s
+}
+
+// RowRatios sets relative heights of rows. It must have exactly one value per row.
+// When user resizes rows, new ratios are written back to the slice.
+func (s *SubplotsWidget) RowRatios(ratios ...float32) *SubplotsWidget {
+	s.rowRatios = ratios
+	return s
+}
+
+// ColRatios sets relative widths of columns. It must have exactly one value per column.
+// When user resizes columns, new ratios are written back to the slice.
+func (s *SubplotsWidget) ColRatios(ratios ...float32) *SubplotsWidget {
+	s.colRatios = ratios
+	return s
+}
+
+// Plots sets plots placed in the grid's cells (row by row unless PlotSubplotFlagsColMajor is set).
+// Sizes of the plots are ignored. Plots without items are displayed empty.
+func (s *SubplotsWidget) Plots(plots ...*PlotCanvasWidget) *SubplotsWidget {
+	s.plots = plots
+	return s
+}
+
+// Build implements Widget interface.
+func (s *SubplotsWidget) Build() {
+	if len(s.plots) == 0 {
+		return
+	}
+
+	Assert(s.rowRatios == nil || len(s.rowRatios) == s.rows, "SubplotsWidget", "Build", "number of row ratios must be equal to number of rows")
+	Assert(s.colRatios == nil || len(s.colRatios) == s.cols, "SubplotsWidget", "Build", "number of column ratios must be equal to number of columns")
+
+	var rowRatios, colRatios *float32
+
+	if len(s.rowRatios) > 0 {
+		rowRatios = &s.rowRatios[0]
+	}
+
+	if len(s.colRatios) > 0 {
+		colRatios = &s.colRatios[0]
+	}
+
+	if implot.BeginSubplotsV(
+		Context.PrepareString(s.title)+"##"+s.id.String(),
+		int32(s.rows),
+		int32(s.cols),
+		ToVec2(image.Pt(s.width, s.height)),
+		implot.SubplotFlags(s.flags),
+		rowRatios,
+		colRatios,
+	) {
+		for _, plot := range s.plots {
+			plot.build()
+		}
+
+		implot.EndSubplots()
+	}
+}
@@ -187,6 +187,83 @@ func (s *StatefulReflectiveBoundTexture) SetSurfaceFromFsFile(file fs.File, comm
 	return s.LoadSurface(NewFsFileLoader(file), commit)
 }
 
+var _ SurfaceLoader = &FSLoader{}
+
+// FSLoader is a SurfaceLoader that loads images from a path in fs.FS (e.g. embed.FS).
+// Unlike FsFileLoader, it opens the file only when the image is served and
+// decodes all image formats registered in image package.
+type FSLoader struct {
+	fsys fs.FS
+	path string
+}
+
+// ServeRGBA loads an image from the file system and returns it as an RGBA image.
+//
+// Returns:
+//   - *image.RGBA: The loaded RGBA image.
+//   - error: An error if the image could not be loaded.
+func (f *FSLoader) ServeRGBA() (*image.RGBA, error) {
+	file, err := f.fsys.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("fsLoader serveRGBA after fs.Open: %w", err)
+	}
+
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("fsLoader serveRGBA after image.Decode: %w", err)
+	}
+
+	return ImageToRgba(img), nil
+}
+
+// NewFSLoader creates a new SurfaceLoader that loads images from the specified path in fsys.
+//
+// Parameters:
+//   - fsys: The file system to load the image from.
+//   - path: The path to the image inside of fsys.
+//
+// Returns:
+//   - SurfaceLoader: A new SurfaceLoader for loading images from the specified file system.
+func NewFSLoader(fsys fs.FS, path string) *FSLoader {
+	return &FSLoader{
+		fsys: fsys,
+		path: path,
+	}
+}
+
+// SetSurfaceFromFS loads an image from the specified path in fsys and sets it as the surface of the ReflectiveBoundTexture.
+//
+// Parameters:
+//   - fsys: The file system to load the image from.
+//   - path: The path to the image inside of fsys.
+//   - commit: A boolean flag indicating whether to commit the changes.
+//
+// Returns:
+//   - error: An error if the image could not be loaded or set as the surface.
+func (i *ReflectiveBoundTexture) SetSurfaceFromFS(fsys fs.FS, path string, commit bool) error {
+	return i.LoadSurface(NewFSLoader(fsys, path), commit)
+}
+
+// SetSurfaceFromFS loads an image from the specified path in fsys and sets it as the surface of the StatefulReflectiveBoundTexture.
+//
+// Parameters:
+//   - fsys: The file system to load the image from.
+//   - path: The path to the image inside of fsys.
+//   - commit: A boolean flag indicating whether to commit the changes.
+//
+// Returns:
+//   - error: An error if the image could not be loaded or set as the surface.
+func (s *StatefulReflectiveBoundTexture) SetSurfaceFromFS(fsys fs.FS, path string, commit bool) error {
+	return s.LoadSurface(NewFSLoader(fsys, path), commit)
+}
+
 var _ SurfaceLoader = &URLLoader{}
 
 // URLLoader is a SurfaceLoader that loads images from a specified URL.
@@ -0,0 +1,287 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TableExportFormat is a text format tables can be exported to.
+type TableExportFormat byte
+
+// Table export formats.
+const (
+	TableExportCSV TableExportFormat = iota
+	TableExportTSV
+)
+
+// Text sets function returning text of the column's cell when the table is exported.
+// By default, text of LabelWidget cells is used and other cells are empty.
+func (c *TableColumnWidget) Text(text func(cell Widget) string) *TableColumnWidget {
+	c.text = text
+	return c
+}
+
+// cellText returns text of the cell w using text function of the column col (may be nil).
+func cellText(col *TableColumnWidget, w Widget) string {
+	if col != nil && col.text != nil {
+		return col.text(w)
+	}
+
+	if label, ok := w.(*LabelWidget); ok {
+		return label.label
+	}
+
+	return ""
+}
+
+// writeTable writes records in format to w.
+func writeTable(w io.Writer, format TableExportFormat, records [][]string) error {
+	writer := csv.NewWriter(w)
+	if format == TableExportTSV {
+		writer.Comma = '\t'
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("writing table: %w", err)
+	}
+
+	return nil
+}
+
+// ClipboardCopy enables copying the header and rows to the clipboard (as TSV, so that they can be pasted
+// to a spreadsheet) with Ctrl+C when the table is hovered or was clicked last.
+// Selected rows are copied if there are any (see Selection), all rows otherwise.
+func (t *TableWidget) ClipboardCopy(b bool) *TableWidget {
+	t.clipboardCopy = b
+	return t
+}
+
+// Export writes header and rows of the table to w. Hidden columns are skipped
+// and the others are written in their display order.
+func (t *TableWidget) Export(w io.Writer, format TableExportFormat) error {
+	columns := exportColumns(t.colCount(), t.layout(t.getState()))
+	return writeTable(w, format, t.exportRecords(columns, nil))
+}
+
+// exportColumns returns indices of count columns that are visible according to layout, in display order.
+// layout may be nil.
+func exportColumns(count int, layout []tableColumnLayout) []int {
+	columns := make([]int, 0, count)
+	for i := range count {
+		if i < len(layout) && !layout[i].Visible {
+			continue
+		}
+
+		columns = append(columns, i)
+	}
+
+	if len(layout) == count {
+		slices.SortFunc(columns, func(a, b int) int {
+			return layout[a].Order - layout[b].Order
+		})
+	}
+
+	return columns
+}
+
+// exportRecords returns header (if the table has one) and rows of the table for which include returns true
+// (or all rows if include is nil) as records. Only listed columns are exported.
+func (t *TableWidget) exportRecords(columns []int, include func(row int) bool) [][]string {
+	var records [][]string
+
+	if len(t.columns) > 0 && !t.noHeader {
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i], _, _ = strings.Cut(t.columns[c].label, "##")
+		}
+
+		records = append(records, header)
+	}
+
+	for r, row := range t.rows {
+		if include != nil && !include(r) {
+			continue
+		}
+
+		cells := row.cells()
+		record := make([]string, len(columns))
+
+		for i, c := range columns {
+			if c >= len(cells) {
+				continue
+			}
+
+			var col *TableColumnWidget
+			if c < len(t.columns) {
+				col = t.columns[c]
+			}
+
+			record[i] = cellText(col, cells[c])
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// selectedRows returns IsSelected of the table's selection if any row is selected, nil otherwise.
+func (t *TableWidget) selectedRows() func(row int) bool {
+	if t.selection == nil {
+		return nil
+	}
+
+	for r := range t.rows {
+		if t.selection.IsSelected(r) {
+			return t.selection.IsSelected
+		}
+	}
+
+	return nil
+}
+
+// isCopyPressed returns true if Ctrl+C was pressed.
+// Check that the table has keyboard (see tableHasKeyboard) first.
+func isCopyPressed() bool {
+	return imgui.IsKeyChordPressed(imgui.KeyChord(imgui.ModCtrl) | imgui.KeyChord(imgui.KeyC))
+}
+
+// copyRecords copies records to the clipboard as TSV.
+func copyRecords(records [][]string) {
+	buf := &bytes.Buffer{}
+	if err := writeTable(buf, TableExportTSV, records); err != nil {
+		return
+	}
+
+	imgui.SetClipboardText(buf.String())
+}
+
+// ClipboardCopy enables copying rows to the clipboard (as TSV) with Ctrl+C
+// when the table is hovered or was clicked last.
+func (tt *TreeTableWidget) ClipboardCopy(b bool) *TreeTableWidget {
+	tt.clipboardCopy = b
+	return tt
+}
+
+// Export writes header and rows of the tree table to w. Labels of tree nodes are indented
+// by two spaces per level. All rows are exported, no matter if their parents are open.
+func (tt *TreeTableWidget) Export(w io.Writer, format TableExportFormat) error {
+	return writeTable(w, format, tt.exportRecords())
+}
+
+// exportRecords returns header (if the table has columns) and all rows of the tree table as records.
+func (tt *TreeTableWidget) exportRecords() [][]string {
+	var records [][]string
+
+	if len(tt.columns) > 0 {
+		header := make([]string, len(tt.columns))
+		for i, col := range tt.columns {
+			header[i], _, _ = strings.Cut(col.label, "##")
+		}
+
+		records = append(records, header)
+	}
+
+	for _, row := range tt.rows {
+		records = row.exportRecords(tt.columns, 0, records)
+	}
+
+	// rows without some cells are padded, so that all the records have a field for each column
+	count := tt.colCount()
+	for i, record := range records {
+		if len(record) < count {
+			records[i] = append(record, make([]string, count-len(record))...)
+		}
+	}
+
+	return records
+}
+
+// exportRecords appends records of the row and its children (at depth level of the tree) to records.
+func (ttr *TreeTableRowWidget) exportRecords(columns []*TableColumnWidget, depth int, records [][]string) [][]string {
+	label, _, _ := strings.Cut(ttr.label.String(), "##")
+	record := []string{strings.Repeat("  ", depth) + label}
+
+	for i, w := range ttr.cells() {
+		var col *TableColumnWidget
+		if i+1 < len(columns) {
+			col = columns[i+1]
+		}
+
+		record = append(record, cellText(col, w))
+	}
+
+	records = append(records, record)
+
+	for _, child := range ttr.children {
+		records = child.exportRecords(columns, depth+1, records)
+	}
+
+	return records
+}
+
+// cells returns widgets of the row placed in columns.
+func (ttr *TreeTableRowWidget) cells() []Widget {
+	var result []Widget
+
+	for _, w := range ttr.layout {
+		if isTableCell(w) {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+// Text sets function returning text of the column for a row when the table is exported.
+// Without it, text of the cell's widget is exported if it is a LabelWidget (other cells are exported empty).
+func (c *DataColumnWidget[T]) Text(text func(row T) string) *DataColumnWidget[T] {
+	c.text = text
+	return c
+}
+
+// ClipboardCopy enables copying the header and rows to the clipboard (as TSV) with Ctrl+C
+// when the table is hovered or was clicked last.
+func (t *DataTableWidget[T]) ClipboardCopy(b bool) *DataTableWidget[T] {
+	t.clipboardCopy = b
+	return t
+}
+
+// Export writes header and rows matching filters to w, in the order they are displayed.
+func (t *DataTableWidget[T]) Export(w io.Writer, format TableExportFormat) error {
+	state := t.getState()
+	return writeTable(w, format, dataTableRecords(t.rows, t.columns, dataTableView(t.rows, t.columns, state.filters, state.sortSpecs)))
+}
+
+// dataTableRecords returns header and rows listed in view as records.
+func dataTableRecords[T any](rows []T, columns []*DataColumnWidget[T], view []int) [][]string {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i], _, _ = strings.Cut(col.header, "##")
+	}
+
+	records := [][]string{header}
+
+	for _, index := range view {
+		record := make([]string, len(columns))
+
+		for i, col := range columns {
+			switch {
+			case col.text != nil:
+				record[i] = col.text(rows[index])
+			case col.cell != nil:
+				record[i] = cellText(nil, col.cell(rows[index]))
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
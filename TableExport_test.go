@@ -0,0 +1,99 @@
+package giu
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_exportColumns(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, exportColumns(3, nil))
+
+	layout := []tableColumnLayout{
+		{Order: 2, Visible: true},
+		{Order: 0, Visible: false},
+		{Order: 1, Visible: true},
+	}
+	assert.Equal(t, []int{2, 0}, exportColumns(3, layout), "hidden column should be skipped")
+}
+
+func TestTableWidget_exportRecords(t *testing.T) {
+	table := &TableWidget{
+		columns: []*TableColumnWidget{
+			{label: "Name##name"},
+			{label: "Size", text: func(cell Widget) string {
+				label, _ := cell.(*LabelWidget)
+				return strconv.Itoa(len(label.label))
+			}},
+			{label: "Action"},
+		},
+		rows: []*TableRowWidget{
+			{layout: Layout{&LabelWidget{label: "a, b"}, &LabelWidget{label: "abc"}, &ButtonWidget{}}},
+			{layout: Layout{&LabelWidget{label: "c"}, &TooltipWidget{}, &LabelWidget{label: "d"}, &ButtonWidget{}}},
+		},
+	}
+
+	records := table.exportRecords([]int{0, 1, 2}, nil)
+	assert.Equal(t, [][]string{
+		{"Name", "Size", "Action"},
+		{"a, b", "3", ""},
+		{"c", "1", ""},
+	}, records)
+
+	selected := RowSet{1: {}}
+	table.noHeader = true
+	records = table.exportRecords([]int{1, 0}, selected.IsSelected)
+	assert.Equal(t, [][]string{{"1", "c"}}, records)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeTable(buf, TableExportCSV, [][]string{{"a, b", "c"}}))
+	assert.Equal(t, "\"a, b\",c\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeTable(buf, TableExportTSV, [][]string{{"a, b", "c"}}))
+	assert.Equal(t, "a, b\tc\n", buf.String())
+}
+
+func TestTreeTableWidget_exportRecords(t *testing.T) {
+	table := &TreeTableWidget{
+		columns: []*TableColumnWidget{{label: "Path"}, {label: "Size"}},
+		rows: []*TreeTableRowWidget{
+			{
+				label:  "src##1",
+				layout: Layout{&LabelWidget{label: "2"}},
+				children: []*TreeTableRowWidget{
+					{label: "main.go##2", layout: Layout{&LabelWidget{label: "1"}}},
+					{label: "util##3", children: []*TreeTableRowWidget{
+						{label: "util.go##4", layout: Layout{&LabelWidget{label: "1"}}},
+					}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, [][]string{
+		{"Path", "Size"},
+		{"src", "2"},
+		{"  main.go", "1"},
+		{"  util", ""},
+		{"    util.go", "1"},
+	}, table.exportRecords())
+}
+
+func Test_dataTableRecords(t *testing.T) {
+	rows := []int{3, 1, 2}
+	columns := []*DataColumnWidget[int]{
+		{header: "Value", text: strconv.Itoa},
+		{header: "Label", cell: func(row int) Widget { return &LabelWidget{label: strconv.Itoa(row * 10)} }},
+		{header: "Chart", cell: func(int) Widget { return &ProgressBarWidget{} }},
+	}
+
+	assert.Equal(t, [][]string{
+		{"Value", "Label", "Chart"},
+		{"1", "10", ""},
+		{"2", "20", ""},
+	}, dataTableRecords(rows, columns, []int{1, 2}), "labels should be exported without Text")
+}
@@ -0,0 +1,235 @@
+package giu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ErrTableLayoutID is returned by LoadLayout when the layout was saved by a table with another ID.
+var ErrTableLayoutID = errors.New("table layout belongs to another table")
+
+// tableLayout is the JSON form of a table layout.
+type tableLayout struct {
+	ID      string              `json:"id"`
+	Columns []tableColumnLayout `json:"columns"`
+}
+
+// tableColumnLayout describes a single column (columns are stored in order of TableWidget.Columns).
+type tableColumnLayout struct {
+	// Order is the position of the column after reordering.
+	Order int `json:"order"`
+	// Width is width of a fixed column or weight of a stretched one (0 means the default).
+	Width   float32 `json:"width"`
+	Stretch bool    `json:"stretch,omitempty"`
+	Visible bool    `json:"visible"`
+	// SortOrder is -1 for unsorted columns.
+	SortOrder int           `json:"sortOrder"`
+	Sort      SortDirection `json:"sort,omitempty"`
+}
+
+// SaveLayout returns JSON describing order, widths, visibility and sorting of columns
+// as the user left them in the last frame. Restore it with LoadLayout
+// (use the same table ID - see ID method).
+func (t *TableWidget) SaveLayout() ([]byte, error) {
+	columns := t.layout(t.getState())
+	if len(columns) == 0 {
+		columns = t.defaultLayout()
+	}
+
+	return encodeTableLayout(t.id.String(), columns)
+}
+
+// LoadLayout restores layout saved by SaveLayout. It is applied when the table is built next time.
+func (t *TableWidget) LoadLayout(data []byte) error {
+	columns, err := decodeTableLayout(data, t.id.String())
+	if err != nil {
+		return err
+	}
+
+	t.getState().pendingLayout = columns
+
+	return nil
+}
+
+// encodeTableLayout returns JSON of columns layout of table id.
+func encodeTableLayout(id string, columns []tableColumnLayout) ([]byte, error) {
+	data, err := json.Marshal(tableLayout{ID: id, Columns: columns})
+	if err != nil {
+		return nil, fmt.Errorf("encoding table layout: %w", err)
+	}
+
+	return data, nil
+}
+
+// decodeTableLayout returns columns layout from JSON saved by table id.
+func decodeTableLayout(data []byte, id string) ([]tableColumnLayout, error) {
+	var layout tableLayout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("decoding table layout: %w", err)
+	}
+
+	if layout.ID != id {
+		return nil, fmt.Errorf("%w: %q", ErrTableLayoutID, layout.ID)
+	}
+
+	return layout.Columns, nil
+}
+
+// HeaderContextMenu enables imgui's context menu of the header (opened with right click)
+// listing columns with visibility checkboxes and adds an item resetting the layout to it.
+// It turns on TableFlagsHideable; the menu also contains sizing and ordering items
+// if TableFlagsResizable or TableFlagsReorderable is set.
+func (t *TableWidget) HeaderContextMenu(b bool) *TableWidget {
+	t.headerContextMenu = b
+	return t
+}
+
+// tableFlags returns flags of the table including those required by HeaderContextMenu.
+func (t *TableWidget) tableFlags() TableFlags {
+	if t.headerContextMenu {
+		return t.flags | TableFlagsHideable
+	}
+
+	return t.flags
+}
+
+// layout reads the current columns layout from imgui's settings of the table.
+// It returns nil if the table was not built yet or it has no settings.
+func (t *TableWidget) layout(state *tableState) []tableColumnLayout {
+	if state.tableID == 0 {
+		return nil
+	}
+
+	return readTableLayout(state.tableID)
+}
+
+// defaultLayout returns layout of columns that were never changed by the user.
+func (t *TableWidget) defaultLayout() []tableColumnLayout {
+	result := make([]tableColumnLayout, len(t.columns))
+	for i, col := range t.columns {
+		result[i] = tableColumnLayout{
+			Order:     i,
+			Visible:   col.flags&TableColumnFlagsDefaultHide == 0,
+			SortOrder: -1,
+		}
+	}
+
+	return result
+}
+
+// readTableLayout reads layout of table id from imgui's table settings.
+// It returns nil if the table has no settings yet.
+func readTableLayout(id imgui.ID) []tableColumnLayout {
+	settings := imgui.InternalTableSettingsFindByID(id)
+	if settings.CData == nil {
+		return nil
+	}
+
+	count := int(settings.ColumnsCount())
+	result := make([]tableColumnLayout, count)
+
+	// column settings are stored in C array right after the table settings.
+	first := settings.InternalColumnSettings()
+	size := unsafe.Sizeof(*first.CData)
+
+	for i := range count {
+		c := imgui.NewTableColumnSettingsFromC(unsafe.Add(unsafe.Pointer(first.CData), uintptr(i)*size))
+
+		index := int(c.Index())
+		if index < 0 || index >= count {
+			continue
+		}
+
+		result[index] = tableColumnLayout{
+			Order:     int(c.DisplayOrder()),
+			Width:     c.WidthOrWeight(),
+			Stretch:   c.IsStretch() != 0,
+			Visible:   c.IsEnabled() != 0,
+			SortOrder: int(c.SortOrder()),
+			Sort:      SortDirection(c.SortDirection()),
+		}
+	}
+
+	return result
+}
+
+// applyTableLayout writes layout to imgui's settings of table id and makes the table load them.
+func applyTableLayout(id imgui.ID, flags TableFlags, layout []tableColumnLayout) {
+	count := len(layout)
+
+	settings := imgui.InternalTableSettingsFindByID(id)
+	if settings.CData != nil && int(settings.ColumnsCountMax()) < count {
+		// settings with zero ID are discarded by imgui
+		settings.SetID(0)
+
+		settings.CData = nil
+	}
+
+	if settings.CData == nil {
+		settings = imgui.InternalTableSettingsCreate(id, int32(count))
+	}
+
+	saveFlags := flags & (TableFlagsReorderable | TableFlagsHideable | TableFlagsSortable)
+
+	// widths are loaded only if all of them are known
+	resizable := true
+
+	first := settings.InternalColumnSettings()
+	size := unsafe.Sizeof(*first.CData)
+
+	for i, col := range layout {
+		c := imgui.NewTableColumnSettingsFromC(unsafe.Add(unsafe.Pointer(first.CData), uintptr(i)*size))
+
+		c.SetIndex(imgui.TableColumnIdx(i))
+		c.SetDisplayOrder(imgui.TableColumnIdx(col.Order))
+		c.SetWidthOrWeight(col.Width)
+		c.SetIsStretch(boolToByte(col.Stretch))
+		c.SetIsEnabled(int(boolToByte(col.Visible)))
+		c.SetSortOrder(imgui.TableColumnIdx(col.SortOrder))
+		c.SetSortDirection(byte(col.Sort))
+
+		resizable = resizable && col.Width > 0
+	}
+
+	if resizable {
+		saveFlags |= flags & TableFlagsResizable
+	}
+
+	settings.SetColumnsCount(imgui.TableColumnIdx(count))
+	settings.SetSaveFlags(imgui.TableFlags(saveFlags))
+	settings.SetRefScale(0)
+
+	if table := imgui.InternalTableFindByID(id); table.CData != nil {
+		table.SetSettingsOffset(-1)
+		table.SetIsSettingsRequestLoad(true)
+		table.SetIsSortSpecsDirty(true)
+	}
+}
+
+// buildHeaderContextMenu appends an item resetting the layout to imgui's context menu of the table.
+func (t *TableWidget) buildHeaderContextMenu() {
+	table := imgui.InternalCurrentTable()
+	if !imgui.InternalTableBeginContextMenuPopup(table) {
+		return
+	}
+
+	imgui.Separator()
+
+	if imgui.MenuItemBool(Context.PrepareString("Reset layout")) {
+		imgui.InternalTableResetSettings(table)
+	}
+
+	imgui.EndPopup()
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,63 @@
+package giu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableWidget_defaultLayout(t *testing.T) {
+	table := &TableWidget{
+		id: "inventory",
+		columns: []*TableColumnWidget{
+			{label: "Name"},
+			{label: "Notes", flags: TableColumnFlagsDefaultHide},
+		},
+	}
+
+	layout := table.defaultLayout()
+	assert.Equal(t, []tableColumnLayout{
+		{Order: 0, Visible: true, SortOrder: -1},
+		{Order: 1, Visible: false, SortOrder: -1},
+	}, layout)
+
+	data, err := json.Marshal(tableLayout{ID: table.id.String(), Columns: layout[:1]})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"inventory","columns":[{"order":0,"width":0,"visible":true,"sortOrder":-1}]}`, string(data))
+}
+
+func Test_tableLayoutRoundTrip(t *testing.T) {
+	columns := []tableColumnLayout{
+		{Order: 1, Width: 120, Visible: true, SortOrder: 0, Sort: SortDescending},
+		{Order: 0, Width: 2, Stretch: true, Visible: false, SortOrder: -1},
+	}
+
+	data, err := encodeTableLayout("inventory", columns)
+	require.NoError(t, err)
+
+	loaded, err := decodeTableLayout(data, "inventory")
+	require.NoError(t, err)
+	assert.Equal(t, columns, loaded)
+}
+
+func Test_decodeTableLayoutMismatchedID(t *testing.T) {
+	data, err := encodeTableLayout("inventory", []tableColumnLayout{{Visible: true, SortOrder: -1}})
+	require.NoError(t, err)
+
+	_, err = decodeTableLayout(data, "orders")
+	require.ErrorIs(t, err, ErrTableLayoutID)
+	assert.Contains(t, err.Error(), `"inventory"`)
+
+	_, err = decodeTableLayout([]byte("{"), "inventory")
+	assert.Error(t, err, "invalid JSON should be rejected")
+}
+
+func TestTableWidget_tableFlags(t *testing.T) {
+	table := &TableWidget{flags: TableFlagsResizable}
+	assert.Equal(t, TableFlagsResizable, table.tableFlags())
+
+	table.headerContextMenu = true
+	assert.Equal(t, TableFlagsResizable|TableFlagsHideable, table.tableFlags(), "header menu should list columns to hide")
+}
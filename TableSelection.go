@@ -0,0 +1,259 @@
+package giu
+
+import (
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TableSelectionMode tells how many rows of a table can be selected.
+type TableSelectionMode byte
+
+// Table selection modes.
+const (
+	// TableSelectionSingle allows to select a single row.
+	TableSelectionSingle TableSelectionMode = iota
+	// TableSelectionMulti allows to select multiple rows: Ctrl+click toggles a row
+	// and Shift+click selects a range of rows.
+	TableSelectionMulti
+)
+
+// TableSelection stores indices of selected table rows.
+// See RowSet and SelectedRows.
+type TableSelection interface {
+	IsSelected(row int) bool
+	SetSelected(row int, selected bool)
+	Clear()
+}
+
+var _ TableSelection = RowSet{}
+
+// RowSet is a TableSelection storing selected rows in a set.
+// Create it with make(RowSet).
+type RowSet map[int]struct{}
+
+// IsSelected implements TableSelection.
+func (s RowSet) IsSelected(row int) bool {
+	_, ok := s[row]
+	return ok
+}
+
+// SetSelected implements TableSelection.
+func (s RowSet) SetSelected(row int, selected bool) {
+	if selected {
+		s[row] = struct{}{}
+	} else {
+		delete(s, row)
+	}
+}
+
+// Clear implements TableSelection.
+func (s RowSet) Clear() {
+	clear(s)
+}
+
+// rowSlice is a TableSelection storing selected rows in a slice.
+type rowSlice struct {
+	rows *[]int
+}
+
+// SelectedRows binds table selection to a slice of row indices.
+// Indices are stored in order of selecting.
+func SelectedRows(rows *[]int) TableSelection {
+	return &rowSlice{rows: rows}
+}
+
+// IsSelected implements TableSelection.
+func (s *rowSlice) IsSelected(row int) bool {
+	return slices.Contains(*s.rows, row)
+}
+
+// SetSelected implements TableSelection.
+func (s *rowSlice) SetSelected(row int, selected bool) {
+	if !selected {
+		*s.rows = slices.DeleteFunc(*s.rows, func(r int) bool { return r == row })
+		return
+	}
+
+	if !s.IsSelected(row) {
+		*s.rows = append(*s.rows, row)
+	}
+}
+
+// Clear implements TableSelection.
+func (s *rowSlice) Clear() {
+	*s.rows = (*s.rows)[:0]
+}
+
+// tableSelect updates selection after the user clicked row (or moved the cursor to it).
+// anchor is the row where range selection starts.
+func tableSelect(selection TableSelection, mode TableSelectionMode, anchor *int, row int, ctrl, shift bool) {
+	if mode == TableSelectionSingle {
+		ctrl, shift = false, false
+	}
+
+	switch {
+	case shift:
+		if !ctrl {
+			selection.Clear()
+		}
+
+		for i := min(*anchor, row); i <= max(*anchor, row); i++ {
+			selection.SetSelected(i, true)
+		}
+	case ctrl:
+		selection.SetSelected(row, !selection.IsSelected(row))
+		*anchor = row
+	default:
+		selection.Clear()
+		selection.SetSelected(row, true)
+		*anchor = row
+	}
+}
+
+// buildSelectable builds a selectable spanning the whole row i. It is built in the first cell
+// and the cell's widget is then placed over it.
+func (t *TableWidget) buildSelectable(state *tableState, i int, height float32) {
+	pos := imgui.CursorPos()
+
+	flags := imgui.SelectableFlagsSpanAllColumns | imgui.SelectableFlagsAllowOverlap
+	if imgui.SelectableBoolV("##row", t.selection.IsSelected(i), flags, imgui.Vec2{Y: height}) {
+		io := imgui.CurrentIO()
+		tableSelect(t.selection, t.selectionMode, &state.anchor, i, io.KeyCtrl(), io.KeyShift())
+		state.cursor = i
+
+		if t.onSelectionChange != nil {
+			t.onSelectionChange()
+		}
+	}
+
+	if state.scrollToCursor && state.cursor == i {
+		if !imgui.IsItemVisible() {
+			imgui.SetScrollHereYV(0.5)
+		}
+
+		state.scrollToCursor = false
+	}
+
+	imgui.SetCursorPos(pos)
+}
+
+// tableHasKeyboard returns true if keyboard shortcuts should be handled by the current table.
+// It must be called between BeginTable and EndTable. active tells whether the table was clicked last
+// in its window; it is updated when a mouse button is clicked.
+// The table handles the keyboard if its window is focused, no text field is active and the table
+// is hovered or active, so other tables of the window don't react.
+func tableHasKeyboard(active *bool) bool {
+	hovered := imgui.TableGetHoveredColumn() >= 0
+	*active = tableActive(*active, hovered, imgui.IsMouseClickedBool(imgui.MouseButtonLeft) ||
+		imgui.IsMouseClickedBool(imgui.MouseButtonRight))
+
+	return (*active || hovered) &&
+		imgui.IsWindowFocusedV(imgui.FocusedFlagsRootAndChildWindows) && !imgui.CurrentIO().WantTextInput()
+}
+
+// tableActive returns whether a table is active after a frame. A click activates the table
+// if it is hovered and deactivates it otherwise.
+func tableActive(active, hovered, clicked bool) bool {
+	if clicked {
+		return hovered
+	}
+
+	return active
+}
+
+// handleKeyboard moves the cursor (and selection) with arrow keys, Page Up/Down, Home and End
+// and starts editing with F2. It must be called only if the table has keyboard (see tableHasKeyboard).
+func (t *TableWidget) handleKeyboard(state *tableState) {
+	io := imgui.CurrentIO()
+	if len(t.rows) == 0 {
+		return
+	}
+
+	if IsKeyPressed(KeyF2) && t.onCellEdit != nil {
+		t.startEditing(state, min(state.cursor, len(t.rows)-1), -1)
+		return
+	}
+
+	if t.selection == nil {
+		return
+	}
+
+	page := max(1, int(imgui.WindowHeight()/imgui.TextLineHeightWithSpacing())-1)
+	cursor := state.cursor
+
+	switch {
+	case IsKeyPressed(KeyUp):
+		cursor--
+	case IsKeyPressed(KeyDown):
+		cursor++
+	case IsKeyPressed(KeyPageUp):
+		cursor -= page
+	case IsKeyPressed(KeyPageDown):
+		cursor += page
+	case IsKeyPressed(KeyHome):
+		cursor = 0
+	case IsKeyPressed(KeyEnd):
+		cursor = len(t.rows) - 1
+	default:
+		return
+	}
+
+	state.cursor = max(0, min(cursor, len(t.rows)-1))
+	state.scrollToCursor = true
+
+	// Ctrl moves the cursor only
+	if io.KeyCtrl() && !io.KeyShift() {
+		return
+	}
+
+	tableSelect(t.selection, t.selectionMode, &state.anchor, state.cursor, false, io.KeyShift())
+
+	if t.onSelectionChange != nil {
+		t.onSelectionChange()
+	}
+}
+
+// startEditing starts editing col-th cell of row-th row. If col is negative,
+// the first editable cell of the row is edited.
+// Only cells containing a LabelWidget are editable.
+func (t *TableWidget) startEditing(state *tableState, row, col int) {
+	for c, w := range t.rows[row].cells() {
+		label, ok := w.(*LabelWidget)
+		if !ok || (col >= 0 && c != col) {
+			continue
+		}
+
+		state.editRow, state.editCol = row, c
+		state.editValue = label.label
+		state.editFocus = true
+
+		return
+	}
+}
+
+// buildCellEditor builds an input field of the edited cell.
+// Enter commits the value, Escape (or clicking away) cancels editing.
+func (t *TableWidget) buildCellEditor(state *tableState) {
+	if state.editFocus {
+		imgui.SetKeyboardFocusHere()
+
+		state.editFocus = false
+	}
+
+	imgui.SetNextItemWidth(-1)
+
+	flags := imgui.InputTextFlagsEnterReturnsTrue | imgui.InputTextFlagsAutoSelectAll
+	if imgui.InputTextWithHint("##edit", "", &state.editValue, flags, nil) {
+		row, col := state.editRow, state.editCol
+		state.editRow = -1
+
+		t.onCellEdit(row, col, state.editValue)
+
+		return
+	}
+
+	if imgui.IsItemDeactivated() {
+		state.editRow = -1
+	}
+}
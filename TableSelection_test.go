@@ -0,0 +1,59 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tableSelect(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        TableSelectionMode
+		ctrl, shift bool
+		expected    []int
+		anchor      int
+	}{
+		{"click", TableSelectionMulti, false, false, []int{5}, 5},
+		{"ctrl+click", TableSelectionMulti, true, false, []int{1, 5}, 5},
+		{"shift+click", TableSelectionMulti, false, true, []int{2, 3, 4, 5}, 2},
+		{"ctrl+shift+click", TableSelectionMulti, true, true, []int{1, 2, 3, 4, 5}, 2},
+		{"single ignores modifiers", TableSelectionSingle, true, true, []int{5}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := []int{1}
+			anchor := 2
+
+			tableSelect(SelectedRows(&rows), tt.mode, &anchor, 5, tt.ctrl, tt.shift)
+			assert.ElementsMatch(t, tt.expected, rows)
+			assert.Equal(t, tt.anchor, anchor)
+		})
+	}
+}
+
+func Test_tableSelectToggle(t *testing.T) {
+	set := RowSet{3: {}, 4: {}}
+	anchor := 0
+
+	tableSelect(set, TableSelectionMulti, &anchor, 3, true, false)
+	assert.Equal(t, RowSet{4: {}}, set, "ctrl+click should deselect a selected row")
+}
+
+func Test_tableActive(t *testing.T) {
+	// two tables in a window: the user clicks the first one, then the second one
+	first, second := false, false
+
+	first, second = tableActive(first, true, true), tableActive(second, false, true)
+	assert.True(t, first)
+	assert.False(t, second, "table which was not clicked should not be active")
+
+	first, second = tableActive(first, false, false), tableActive(second, true, false)
+	assert.True(t, first, "moving the mouse away should keep the table active")
+	assert.False(t, second)
+
+	first, second = tableActive(first, false, true), tableActive(second, true, true)
+	assert.False(t, first, "clicking another table should deactivate the table")
+	assert.True(t, second)
+}
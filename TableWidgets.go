@@ -2,6 +2,7 @@ package giu
 
 import (
 	"image/color"
+	"unsafe"
 
 	"github.com/AllenDang/cimgui-go/imgui"
 )
@@ -15,6 +16,40 @@ const (
 	SortDescending SortDirection = 2
 )
 
+// ColumnSortSpec describes sorting by a single column.
+type ColumnSortSpec struct {
+	// ColumnIndex is index of the column in the table.
+	ColumnIndex int
+	// UserID is the ID set by TableColumnWidget.UserID.
+	UserID uint32
+	// Direction is the sort direction.
+	Direction SortDirection
+}
+
+// tableSortSpecs returns sort specs of the current table in priority order.
+func tableSortSpecs(specs *imgui.TableSortSpecs) []ColumnSortSpec {
+	count := int(specs.SpecsCount())
+	if count == 0 {
+		return nil
+	}
+
+	// specs.Specs() points to the first element of C array of count specs.
+	first := specs.Specs()
+	size := unsafe.Sizeof(*first.CData)
+	result := make([]ColumnSortSpec, count)
+
+	for i := range result {
+		spec := imgui.NewTableColumnSortSpecsFromC(unsafe.Add(unsafe.Pointer(first.CData), uintptr(i)*size))
+		result[i] = ColumnSortSpec{
+			ColumnIndex: int(spec.ColumnIndex()),
+			UserID:      uint32(spec.ColumnUserID()),
+			Direction:   SortDirection(spec.SortDirection()),
+		}
+	}
+
+	return result
+}
+
 // TableRowWidget represents a row in a table.
 type TableRowWidget struct {
 	flags        TableRowFlags
@@ -55,18 +90,27 @@ func (r *TableRowWidget) MinHeight(height float64) *TableRowWidget {
 
 // BuildTableRow executes table row build steps.
 func (r *TableRowWidget) BuildTableRow() {
+	r.buildCells(func(_ int, w Widget) {
+		w.Build()
+	})
+}
+
+// buildCells builds the row calling cell (instead of Build) for each widget placed in a column.
+func (r *TableRowWidget) buildCells(cell func(col int, w Widget)) {
 	imgui.TableNextRowV(imgui.TableRowFlags(r.flags), float32(r.minRowHeight))
 
+	col := 0
+
 	for _, w := range r.layout {
-		switch w.(type) {
-		case *TooltipWidget,
-			*ContextMenuWidget, *PopupModalWidget:
-			// noop
-		default:
-			imgui.TableNextColumn()
+		if !isTableCell(w) {
+			w.Build()
+			continue
 		}
 
-		w.Build()
+		imgui.TableNextColumn()
+		cell(col, w)
+
+		col++
 	}
 
 	if r.bgColor != nil {
@@ -74,6 +118,30 @@ func (r *TableRowWidget) BuildTableRow() {
 	}
 }
 
+// cells returns widgets of the row placed in columns.
+func (r *TableRowWidget) cells() []Widget {
+	var result []Widget
+
+	for _, w := range r.layout {
+		if isTableCell(w) {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+// isTableCell returns false for widgets that attach to the previous item instead of taking a column.
+func isTableCell(w Widget) bool {
+	switch w.(type) {
+	case *TooltipWidget,
+		*ContextMenuWidget, *PopupModalWidget:
+		return false
+	default:
+		return true
+	}
+}
+
 // TableColumnWidget allows to configure table columns headers.
 type TableColumnWidget struct {
 	label              string
@@ -81,6 +149,7 @@ type TableColumnWidget struct {
 	innerWidthOrWeight float32
 	userID             uint32
 	sortFn             func(SortDirection)
+	text               func(cell Widget) string
 }
 
 // TableColumn creates a new TableColumnWidget.
@@ -139,6 +208,15 @@ type TableWidget struct {
 	freezeRow    int
 	freezeColumn int
 	noHeader     bool
+	onSort       func([]ColumnSortSpec)
+
+	headerContextMenu bool
+	clipboardCopy     bool
+
+	selection         TableSelection
+	selectionMode     TableSelectionMode
+	onSelectionChange func()
+	onCellEdit        func(row, col int, value string)
 }
 
 // Table creates new TableWidget.
@@ -191,6 +269,37 @@ func (t *TableWidget) Columns(cols ...*TableColumnWidget) *TableWidget {
 	return t
 }
 
+// OnSort sets callback called when the user changes sorting of a sortable table.
+// It receives sort specs of all sorted columns in priority order
+// (add TableFlagsSortMulti to allow sorting by several columns with Shift held).
+func (t *TableWidget) OnSort(onSort func([]ColumnSortSpec)) *TableWidget {
+	t.onSort = onSort
+	return t
+}
+
+// Selection makes rows selectable by clicking and with keyboard (arrows, Page Up/Down, Home, End)
+// when the table is hovered or was clicked last. Selected rows are stored in selection (see RowSet and SelectedRows).
+func (t *TableWidget) Selection(selection TableSelection, mode TableSelectionMode) *TableWidget {
+	t.selection = selection
+	t.selectionMode = mode
+
+	return t
+}
+
+// OnSelectionChange sets callback called when the user changes selection.
+func (t *TableWidget) OnSelectionChange(onChange func()) *TableWidget {
+	t.onSelectionChange = onChange
+	return t
+}
+
+// OnCellEdit makes cells containing a LabelWidget editable. Editing starts with double click
+// (or F2 on the selected row), Enter commits the value and Escape cancels editing.
+// The callback receives indices of the row and column (not counting tooltips etc.) and the new value.
+func (t *TableWidget) OnCellEdit(onEdit func(row, col int, value string)) *TableWidget {
+	t.onCellEdit = onEdit
+	return t
+}
+
 // Rows sets the rows of the table.
 func (t *TableWidget) Rows(rows ...*TableRowWidget) *TableWidget {
 	t.rows = rows
@@ -231,26 +340,42 @@ func (t *TableWidget) colCount() int {
 	return colCount
 }
 
+// handleSort calls sort callbacks when the user changed sorting.
+// Per-column Sort callback is called for the primary sort column only.
 func (t *TableWidget) handleSort() {
-	if specs := imgui.TableGetSortSpecs(); specs != nil {
-		if specs.SpecsDirty() {
-			// Evil bithack - we assume that array==pointer, so specs.Specs() points to the first element of that array.
-			cs := specs.Specs() // this in fact is []TableColumnSortSpecs but should be also (*TableColumnSortSpecs)
-			colIdx := cs.ColumnIndex()
-			sortDir := cs.SortDirection()
-
-			if col := t.columns[colIdx]; col.sortFn != nil {
-				col.sortFn(SortDirection(sortDir))
-			}
+	specs := imgui.TableGetSortSpecs()
+	if specs == nil || !specs.SpecsDirty() {
+		return
+	}
+
+	sortSpecs := tableSortSpecs(specs)
 
-			specs.SetSpecsDirty(false)
+	if len(sortSpecs) > 0 && sortSpecs[0].ColumnIndex < len(t.columns) {
+		if col := t.columns[sortSpecs[0].ColumnIndex]; col.sortFn != nil {
+			col.sortFn(sortSpecs[0].Direction)
 		}
 	}
+
+	if t.onSort != nil {
+		t.onSort(sortSpecs)
+	}
+
+	specs.SetSpecsDirty(false)
 }
 
 // Build implements Widget interface.
 func (t *TableWidget) Build() {
-	if imgui.BeginTableV(t.id.String(), int32(t.colCount()), imgui.TableFlags(t.flags), t.size, float32(t.innerWidth)) {
+	state := t.getState()
+	state.tableID = imgui.IDStr(t.id.String())
+
+	flags := t.tableFlags()
+
+	if state.pendingLayout != nil {
+		applyTableLayout(state.tableID, flags, state.pendingLayout)
+		state.pendingLayout = nil
+	}
+
+	if imgui.BeginTableV(t.id.String(), int32(t.colCount()), imgui.TableFlags(flags), t.size, float32(t.innerWidth)) {
 		if t.freezeColumn >= 0 && t.freezeRow >= 0 {
 			imgui.TableSetupScrollFreeze(int32(t.freezeColumn), int32(t.freezeRow))
 		}
@@ -262,30 +387,53 @@ func (t *TableWidget) Build() {
 
 			if !t.noHeader {
 				imgui.TableHeadersRow()
+
+				if t.headerContextMenu {
+					t.buildHeaderContextMenu()
+				}
 			}
 
-			if t.flags&TableFlags(imgui.TableFlagsSortable) != 0 {
+			if flags&TableFlags(imgui.TableFlagsSortable) != 0 {
 				t.handleSort()
 			}
 		}
 
+		hasKeyboard := tableHasKeyboard(&state.active)
+
+		// rows are built with state only if they are selectable or editable
+		var rowState *tableState
+		if t.selection != nil || t.onCellEdit != nil {
+			rowState = state
+
+			if hasKeyboard {
+				t.handleKeyboard(state)
+			}
+		}
+
+		if t.clipboardCopy && hasKeyboard && isCopyPressed() {
+			copyRecords(t.exportRecords(exportColumns(t.colCount(), t.layout(state)), t.selectedRows()))
+		}
+
 		if t.fastMode {
 			clipper := imgui.NewListClipper()
 			defer clipper.Destroy()
 
 			clipper.Begin(int32(len(t.rows)))
 
+			if rowState != nil && state.scrollToCursor && state.cursor < len(t.rows) {
+				clipper.IncludeItemByIndex(int32(state.cursor))
+			}
+
 			for clipper.Step() {
 				for i := clipper.DisplayStart(); i < clipper.DisplayEnd(); i++ {
-					row := t.rows[i]
-					row.BuildTableRow()
+					t.buildRow(rowState, int(i))
 				}
 			}
 
 			clipper.End()
 		} else {
-			for _, row := range t.rows {
-				row.BuildTableRow()
+			for i := range t.rows {
+				t.buildRow(rowState, i)
 			}
 		}
 
@@ -293,6 +441,69 @@ func (t *TableWidget) Build() {
 	}
 }
 
+// tableState holds keyboard cursor, edited cell and columns layout of a TableWidget.
+type tableState struct {
+	cursor         int
+	anchor         int
+	scrollToCursor bool
+	// active is true if the table was clicked last (see tableHasKeyboard).
+	active bool
+
+	// editRow is -1 when no cell is edited.
+	editRow, editCol int
+	editValue        string
+	editFocus        bool
+
+	// tableID is imgui's ID of the table (known after it is built), pendingLayout is applied
+	// before the table is built next time.
+	tableID       imgui.ID
+	pendingLayout []tableColumnLayout
+}
+
+// Dispose implements Disposable interface.
+func (s *tableState) Dispose() {
+	// noop
+}
+
+func (t *TableWidget) getState() *tableState {
+	state := GetState[tableState](Context, t.id)
+	if state == nil {
+		state = &tableState{editRow: -1}
+		SetState(Context, t.id, state)
+	}
+
+	return state
+}
+
+// buildRow builds i-th row. state is nil if rows are neither selectable nor editable.
+func (t *TableWidget) buildRow(state *tableState, i int) {
+	row := t.rows[i]
+	if state == nil {
+		row.BuildTableRow()
+		return
+	}
+
+	imgui.PushIDInt(int32(i))
+	defer imgui.PopID()
+
+	row.buildCells(func(col int, w Widget) {
+		if col == 0 && t.selection != nil {
+			t.buildSelectable(state, i, float32(row.minRowHeight))
+		}
+
+		if state.editRow == i && state.editCol == col {
+			t.buildCellEditor(state)
+			return
+		}
+
+		w.Build()
+
+		if _, ok := w.(*LabelWidget); ok && t.onCellEdit != nil && IsItemHovered() && IsMouseDoubleClicked(MouseButtonLeft) {
+			t.startEditing(state, i, col)
+		}
+	})
+}
+
 // TreeTableRowWidget is a row in TreeTableWidget.
 type TreeTableRowWidget struct {
 	label    ID
@@ -366,6 +577,8 @@ type TreeTableWidget struct {
 	rows         []*TreeTableRowWidget
 	freezeRow    int
 	freezeColumn int
+
+	clipboardCopy bool
 }
 
 // TreeTable creates new TreeTableWidget.
@@ -410,18 +623,42 @@ func (tt *TreeTableWidget) Rows(rows ...*TreeTableRowWidget) *TreeTableWidget {
 	return tt
 }
 
+// colCount returns count of columns of the tree table (the first one contains tree nodes).
+func (tt *TreeTableWidget) colCount() int {
+	if len(tt.columns) > 0 || len(tt.rows) == 0 {
+		return len(tt.columns)
+	}
+
+	return len(tt.rows[0].layout) + 1
+}
+
+// treeTableState remembers if the tree table was clicked last (see tableHasKeyboard).
+type treeTableState struct {
+	active bool
+}
+
+// Dispose implements Disposable interface.
+func (s *treeTableState) Dispose() {
+	// noop
+}
+
+func (tt *TreeTableWidget) getState() *treeTableState {
+	state := GetState[treeTableState](Context, tt.id)
+	if state == nil {
+		state = &treeTableState{}
+		SetState(Context, tt.id, state)
+	}
+
+	return state
+}
+
 // Build implements Widget interface.
 func (tt *TreeTableWidget) Build() {
 	if len(tt.rows) == 0 {
 		return
 	}
 
-	colCount := len(tt.columns)
-	if colCount == 0 {
-		colCount = len(tt.rows[0].layout) + 1
-	}
-
-	if imgui.BeginTableV(tt.id.String(), int32(colCount), imgui.TableFlags(tt.flags), tt.size, 0) {
+	if imgui.BeginTableV(tt.id.String(), int32(tt.colCount()), imgui.TableFlags(tt.flags), tt.size, 0) {
 		if tt.freezeColumn >= 0 && tt.freezeRow >= 0 {
 			imgui.TableSetupScrollFreeze(int32(tt.freezeColumn), int32(tt.freezeRow))
 		}
@@ -434,6 +671,10 @@ func (tt *TreeTableWidget) Build() {
 			imgui.TableHeadersRow()
 		}
 
+		if tt.clipboardCopy && tableHasKeyboard(&tt.getState().active) && isCopyPressed() {
+			copyRecords(tt.exportRecords())
+		}
+
 		for _, row := range tt.rows {
 			row.BuildTreeTableRow()
 		}
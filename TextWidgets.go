@@ -518,9 +518,11 @@ var _ Widget = &LabelWidget{}
 
 // LabelWidget is a plain text label.
 type LabelWidget struct {
-	label    string
-	fontInfo *FontInfo
-	wrapped  bool
+	label      string
+	fontInfo   *FontInfo
+	fontFamily string
+	fontStyle  FontStyle
+	wrapped    bool
 }
 
 // Label constructs label widget.
@@ -548,6 +550,24 @@ func (l *LabelWidget) Font(font *FontInfo) *LabelWidget {
 	return l
 }
 
+// FontFamily sets font family (see FontAtlas.AddFontFamilyFS).
+func (l *LabelWidget) FontFamily(family string) *LabelWidget {
+	l.fontFamily = family
+	return l
+}
+
+// Bold uses bold variant of the font family.
+func (l *LabelWidget) Bold() *LabelWidget {
+	l.fontStyle |= FontStyleBold
+	return l
+}
+
+// Italic uses italic variant of the font family.
+func (l *LabelWidget) Italic() *LabelWidget {
+	l.fontStyle |= FontStyleItalic
+	return l
+}
+
 // Build implements Widget interface.
 func (l *LabelWidget) Build() {
 	if l.wrapped {
@@ -556,8 +576,8 @@ func (l *LabelWidget) Build() {
 		defer PopTextWrapPos()
 	}
 
-	if l.fontInfo != nil {
-		if PushFont(l.fontInfo) {
+	if fontInfo := Context.FontAtlas.resolveFont(l.fontInfo, l.fontFamily, l.fontStyle); fontInfo != nil {
+		if PushFont(fontInfo) {
 			defer PopFont()
 		}
 	}
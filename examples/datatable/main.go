@@ -0,0 +1,57 @@
+// Package main presents DataTable - a table bound to a slice of rows with built-in sorting and filtering.
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	g "github.com/AllenDang/giu"
+)
+
+type item struct {
+	sku      string
+	name     string
+	quantity int
+	price    float64
+}
+
+var inventory []item
+
+func loop() {
+	g.SingleWindow().Layout(
+		g.Labelf("%d items; hold Shift to sort by multiple columns", len(inventory)),
+		g.DataTable(inventory).
+			Flags(g.TableFlagsResizable|g.TableFlagsBorders|g.TableFlagsRowBg|g.TableFlagsScrollY|g.TableFlagsSortable|g.TableFlagsSortMulti).
+			Columns(
+				g.DataColumn("SKU", func(i item) g.Widget { return g.Label(i.sku) }).
+					Compare(func(a, b item) int { return strings.Compare(a.sku, b.sku) }).
+					Filter(func(i item, query string) bool { return strings.HasPrefix(i.sku, query) }),
+				g.DataColumn("Name", func(i item) g.Widget { return g.Label(i.name) }).
+					Compare(func(a, b item) int { return strings.Compare(a.name, b.name) }).
+					Filter(func(i item, query string) bool { return strings.Contains(i.name, query) }),
+				g.DataColumn("Quantity", func(i item) g.Widget { return g.Labelf("%d", i.quantity) }).
+					Compare(func(a, b item) int { return cmp.Compare(a.quantity, b.quantity) }),
+				g.DataColumn("Price", func(i item) g.Widget { return g.Labelf("%.2f", i.price) }).
+					Compare(func(a, b item) int { return cmp.Compare(a.price, b.price) }),
+			),
+	)
+}
+
+func main() {
+	names := []string{"bolt", "nut", "washer", "screw", "bracket", "hinge"}
+
+	inventory = make([]item, 200000)
+	for i := range inventory {
+		inventory[i] = item{
+			sku:      fmt.Sprintf("SKU-%06d", i),
+			name:     names[rand.Intn(len(names))],
+			quantity: rand.Intn(1000),
+			price:    rand.Float64() * 100,
+		}
+	}
+
+	wnd := g.NewMasterWindow("Data table", 800, 600, 0)
+	wnd.Run(loop)
+}
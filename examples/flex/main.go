@@ -0,0 +1,50 @@
+// Package main presents Flex container: a toolbar with a search field filling the remaining space,
+// three panels sharing width 1:2:1 and a wrapping list of tags.
+package main
+
+import (
+	"fmt"
+
+	g "github.com/AllenDang/giu"
+)
+
+var search string
+
+func panel(title string) g.Widget {
+	return g.Child().Layout(g.Label(title))
+}
+
+func tags() []g.Widget {
+	result := make([]g.Widget, 20)
+	for i := range result {
+		result[i] = g.Button(fmt.Sprintf("tag %d", i))
+	}
+
+	return result
+}
+
+func loop() {
+	g.SingleWindow().Layout(
+		g.Flex(g.FlexRow).Gap(4).Align(g.FlexAlignCenter).Items(
+			g.Button("New"),
+			g.Button("Open"),
+			g.FlexItem(g.InputText(&search).Hint("Search")).Grow(1),
+			g.Button("Settings"),
+		),
+		g.Flex(g.FlexRow).Gap(4).Wrap(true).Items(tags()...),
+		g.Flex(g.FlexRow).Gap(4).Items(
+			g.FlexItem(panel("Files")).Basis(0).Grow(1),
+			g.FlexItem(panel("Editor")).Basis(0).Grow(2),
+			g.FlexItem(panel("Outline")).Basis(0).Grow(1),
+		).Size(0, 300),
+		g.Flex(g.FlexRow).Justify(g.FlexJustifyEnd).Gap(4).Items(
+			g.Button("Cancel"),
+			g.Button("OK"),
+		),
+	)
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Flex", 800, 600, 0)
+	wnd.Run(loop)
+}
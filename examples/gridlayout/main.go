@@ -0,0 +1,50 @@
+// Package main presents GridLayout: a form with aligned labels and a dashboard
+// with tiles spanning several cells.
+package main
+
+import (
+	g "github.com/AllenDang/giu"
+)
+
+var (
+	name, email, city string
+	age               int32
+)
+
+func tile(title string) g.Widget {
+	return g.Child().Layout(g.Label(title))
+}
+
+func loop() {
+	g.SingleWindow().Layout(
+		g.GridLayout().
+			Columns(g.GridAuto(), g.GridFraction(1)).
+			Gap(8, 4).
+			Items(
+				g.GridItem(0, 0, g.Label("Name")),
+				g.GridItem(0, 1, g.InputText(&name)),
+				g.GridItem(1, 0, g.Label("E-mail address")),
+				g.GridItem(1, 1, g.InputText(&email)),
+				g.GridItem(2, 0, g.Label("Age")),
+				g.GridItem(2, 1, g.InputInt(&age)),
+				g.GridItem(3, 0, g.Label("City")),
+				g.GridItem(3, 1, g.InputText(&city)),
+			),
+		g.Separator(),
+		g.GridLayout().
+			Columns(g.GridFraction(1), g.GridFraction(1), g.GridFraction(1)).
+			Rows(g.GridFixed(100), g.GridFixed(100), g.GridFixed(150)).
+			Gap(8, 8).
+			Items(
+				g.GridItem(0, 0, tile("Sales")).Span(2, 2),
+				g.GridItem(0, 2, tile("Visitors")),
+				g.GridItem(1, 2, tile("Orders")),
+				g.GridItem(2, 0, tile("Recent activity")).Span(1, 3),
+			),
+	)
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Grid layout", 800, 600, 0)
+	wnd.Run(loop)
+}
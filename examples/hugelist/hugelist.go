@@ -8,7 +8,10 @@ import (
 	g "github.com/AllenDang/giu"
 )
 
-var names []string
+var (
+	names    []string
+	selected = make(g.RowSet)
+)
 
 func buildRows() []*g.TableRowWidget {
 	rows := make([]*g.TableRowWidget, len(names))
@@ -28,7 +31,15 @@ func buildRows() []*g.TableRowWidget {
 func loop() {
 	g.SingleWindow().Layout(
 		g.Label("Note: FastTable only works if all rows have same height"),
-		g.Table().Freeze(0, 1).FastMode(true).Rows(buildRows()...),
+		g.Labelf("%d rows selected (double click or press F2 to edit a name)", len(selected)),
+		g.Table().Freeze(0, 1).FastMode(true).
+			Selection(selected, g.TableSelectionMulti).
+			OnCellEdit(func(row, col int, value string) {
+				if col == 1 {
+					names[row] = value
+				}
+			}).
+			Rows(buildRows()...),
 	)
 }
 
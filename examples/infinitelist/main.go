@@ -0,0 +1,93 @@
+// Package main presents InfiniteList: a feed loading pages as you scroll down
+// and a chat loading its history as you scroll up.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	g "github.com/AllenDang/giu"
+)
+
+const pageSize = 50
+
+var errNetwork = errors.New("network error")
+
+// loadPage simulates a slow and unreliable server.
+func loadPage(cursor string) (items []string, next string, err error) {
+	time.Sleep(time.Second)
+
+	if rand.Intn(5) == 0 {
+		return nil, "", errNetwork
+	}
+
+	page, _ := strconv.Atoi(cursor)
+
+	items = make([]string, pageSize)
+	for i := range items {
+		items[i] = fmt.Sprintf("Post #%d", page*pageSize+i)
+	}
+
+	return items, strconv.Itoa(page + 1), nil
+}
+
+// loadHistory returns older messages (in chronological order) until there are 500 of them.
+func loadHistory(cursor string) (items []string, next string, err error) {
+	time.Sleep(500 * time.Millisecond)
+
+	page, _ := strconv.Atoi(cursor)
+
+	items = make([]string, pageSize)
+	for i := range items {
+		items[i] = fmt.Sprintf("Old message #%d", -(page+1)*pageSize+i)
+	}
+
+	if page == 9 {
+		return items, "", nil
+	}
+
+	return items, strconv.Itoa(page + 1), nil
+}
+
+var (
+	sashPos float32 = 300
+	message string
+	sent    []string
+)
+
+func loop() {
+	chat := g.InfiniteList(loadHistory, func(item string) g.Widget {
+		return g.Label(item)
+	}).ID("chat").Reverse(true).Size(0, 300)
+
+	if len(sent) > 0 {
+		chat.Append(sent...)
+		sent = nil
+	}
+
+	g.SingleWindow().Layout(
+		g.SplitLayout(g.DirectionHorizontal, &sashPos,
+			g.InfiniteList(loadPage, func(item string) g.Widget {
+				return g.Label(item)
+			}).ID("feed"),
+			g.Layout{
+				chat,
+				g.Row(
+					g.InputText(&message),
+					g.Button("Send").OnClick(func() {
+						sent = append(sent, message)
+						message = ""
+					}),
+				),
+			},
+		),
+	)
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Infinite list", 800, 600, 0)
+	wnd.Run(loop)
+}
@@ -0,0 +1,94 @@
+// Package main presents LazyTreeTable - a tree table browsing the filesystem.
+// Directories are listed only when they are expanded.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	g "github.com/AllenDang/giu"
+)
+
+// fileTree is a TreeDataProvider of file paths.
+type fileTree struct{}
+
+func (fileTree) Children(path string) []string {
+	// slow down listing to show the spinner
+	time.Sleep(300 * time.Millisecond)
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil
+	}
+
+	// directories first
+	slices.SortStableFunc(entries, func(a, b os.DirEntry) int {
+		switch {
+		case a.IsDir() == b.IsDir():
+			return strings.Compare(a.Name(), b.Name())
+		case a.IsDir():
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	children := make([]string, len(entries))
+	for i, e := range entries {
+		children[i] = filepath.Join(path, e.Name())
+	}
+
+	return children
+}
+
+func (fileTree) HasChildren(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func row(path string) (label string, cells g.Layout) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return filepath.Base(path), g.Layout{g.Label(err.Error())}
+	}
+
+	size := ""
+	if !info.IsDir() {
+		size = fmt.Sprintf("%d", info.Size())
+	}
+
+	return filepath.Base(path), g.Layout{
+		g.Label(size),
+		g.Label(info.ModTime().Format(time.DateTime)),
+	}
+}
+
+var root string
+
+func loop() {
+	g.SingleWindow().Layout(
+		g.LazyTreeTable[string](fileTree{}, root).
+			Async(true).
+			Row(row).
+			Freeze(0, 1).
+			Columns(
+				g.TableColumn("Name"),
+				g.TableColumn("Size").Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(100),
+				g.TableColumn("Modified").Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(150),
+			),
+	)
+}
+
+func main() {
+	var err error
+	if root, err = os.Getwd(); err != nil {
+		panic(err)
+	}
+
+	wnd := g.NewMasterWindow("Lazy tree table", 800, 600, 0)
+	wnd.Run(loop)
+}
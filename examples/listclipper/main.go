@@ -0,0 +1,61 @@
+// Package main presents ListClipper displaying a million lines of log
+// with widgets created only for the visible lines.
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	g "github.com/AllenDang/giu"
+)
+
+const lines = 1_000_000
+
+var (
+	jumpTo int32
+	jump   bool
+)
+
+// stack trace is logged every 100 lines, so the items have different heights.
+func logLine(i int) g.Widget {
+	if i%100 == 99 {
+		return g.Label(fmt.Sprintf("#%d ERROR something failed\n%s", i, strings.Repeat("    at somewhere\n", 3)))
+	}
+
+	return g.Labelf("#%d INFO everything is fine", i)
+}
+
+func loop() {
+	_, lineHeight := g.CalcTextSize("#")
+
+	clipper := g.ListClipper().
+		Items(lines, logLine).
+		ItemHeight(func(i int) float32 {
+			if i%100 == 99 {
+				return 5 * lineHeight
+			}
+
+			return lineHeight
+		})
+
+	if jump {
+		clipper.ScrollTo(int(jumpTo))
+
+		jump = false
+	}
+
+	g.SingleWindow().Layout(
+		g.Row(
+			g.InputInt(&jumpTo).Size(100),
+			g.Button("Jump").OnClick(func() {
+				jump = true
+			}),
+		),
+		g.Child().Layout(clipper),
+	)
+}
+
+func main() {
+	wnd := g.NewMasterWindow("List clipper", 640, 480, 0)
+	wnd.Run(loop)
+}
@@ -1,5 +1,4 @@
 // Package main shows how to use the Markdown widget.
-// NOTE: Ths feature is already disabled in giu as cimgui-go doesn't implement it yet.
 package main
 
 import (
@@ -21,16 +20,39 @@ _emphasis_
 **strong emphasis**
 __strong emphasis__
 
-Indents:
-On a new line, at the start of the line, add two spaces per indent.
-  Indent level 1
-    Indent level 2
+~~strikethrough~~
+` + "`inline code`" + `
 
 Unordered lists:
-On a new line, at the start of the line, add two spaces, an asterisks and a space.
-For nested lists, add two additional spaces in front of the asterisk per list level increment.
-  * Unordered List level 1
-    * Unordered List level 2
+* Unordered List level 1
+  * Unordered List level 2
+
+Ordered lists:
+1. First
+2. Second
+   1. Nested first
+   2. Nested second
+
+Task lists:
+- [x] done
+- [ ] to do
+
+Block quotes:
+> Quoted text
+>> Nested quote
+
+Code blocks:
+` + "```go" + `
+func main() {
+	fmt.Println("Hello giu!")
+}
+` + "```" + `
+
+Tables:
+| Left | Center | Right |
+|:-----|:------:|------:|
+| a    | b      | c     |
+| **bold** | *italic* | ` + "`code`" + ` |
 
 Link:
 Here is [a link to some cool website!](https://github.com/AllenDang/giu) you must click it!
@@ -72,6 +94,6 @@ func loop() {
 }
 
 func main() {
-	wnd := giu.NewMasterWindow("ImGui Markdown [Demo]", 640, 480, 0)
+	wnd := giu.NewMasterWindow("Markdown [Demo]", 640, 480, 0)
 	wnd.Run(loop)
 }
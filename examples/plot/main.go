@@ -3,8 +3,11 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"time"
 
 	"golang.org/x/image/colornames"
@@ -21,24 +24,82 @@ var (
 	bardata3     []float64
 	timeDataMin  float64
 	timeDataMax  float64
-	timeDataX    []float64
+	timeDataX    []time.Time
 	timeDataY    []float64
 	timeScatterY []float64
 	scatterdata  []float64
+	samples      []int16
+	histdata     []float64
+	heatmapdata  []float64
+	errXs        []float64
+	errYs        []float64
+	errs         []float64
+	streamData   = g.NewPlotRingBuffer(1000)
+	cursor1      = 2.0
+	cursor2      = 6.0
+	peakX, peakY = 5.0, 0.5
+	hovered      string
+	selection    string
+	linkedX      = g.NewPlotRange(0, 100)
+	line2Hidden  bool
+	toggled      string
+	stockDates   []float64
+	stockOpen    []float64
+	stockClose   []float64
+	stockLow     []float64
+	stockHigh    []float64
+	stockVolume  []float64
 )
 
+// saveExport saves a plot exported from its context menu to the temporary directory.
+// A real application would ask the user where to save it.
+func saveExport(format g.PlotExportFormat, data []byte, err error) {
+	if err != nil {
+		log.Printf("exporting plot: %v", err)
+		return
+	}
+
+	filename := filepath.Join(os.TempDir(), "plot."+string(format))
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		log.Print(err)
+		return
+	}
+
+	log.Printf("plot saved to %s", filename)
+}
+
 func loop() {
 	g.SingleWindow().Layout(
 		g.Plot("Plot 基本图表").AxisLimits(0, 100, -1.2, 1.2, g.ConditionOnce).XTicks(lineTicks, false).Plots(
 			g.Line("Plot Line 线图", linedata),
-			g.Line("Plot Line2", linedata2),
+			g.Line("Plot Line2", linedata2).Color(colornames.Orange).LineWeight(2).Hidden(&line2Hidden),
 			g.SwitchPlotAxes(g.AxisX1, g.AxisY2),
-			g.Scatter("Scatter 散点图", scatterdata),
-		).SetYAxisLabel(g.AxisY2, "secondary axis"),
+			g.Scatter("Scatter 散点图", scatterdata).Marker(g.PlotMarkerDiamond, 3),
+			g.LineOf("int16 samples", samples).XScale(10),
+		).SetYAxisLabel(g.AxisY2, "secondary axis").
+			ExportMenu(saveExport).
+			Legend(g.PlotLocationNorthEast, g.PlotLegendFlagsHorizontal|g.PlotLegendFlagsOutside).
+			OnLegendToggle(func(label string, visible bool) {
+				toggled = fmt.Sprintf("%s visible: %v", label, visible)
+			}),
+		g.Row(
+			g.Checkbox("Hide Plot Line2", &line2Hidden),
+			g.Label(toggled),
+		),
 		g.Plot("Plot Time Axe 时间线").AxisLimits(timeDataMin, timeDataMax, 0, 1, g.ConditionOnce).Plots(
-			g.LineXY("Time Line 时间线", timeDataX, timeDataY),
-			g.ScatterXY("Time Scatter 时间散点图", timeDataX, timeScatterY),
-		).XScale(g.PlotScaleTime),
+			g.LineTime("Time Line 时间线", timeDataX, timeDataY),
+			g.ScatterTime("Time Scatter 时间散点图", timeDataX, timeScatterY),
+		).TimeAxis(true),
+		g.Plot("Stock prices").
+			Size(-1, 250).
+			TimeAxis(false).
+			SetYAxisLabel(g.AxisY2, "volume").
+			SetAxisLimits(g.AxisY2, 0, 4000, g.ConditionOnce).
+			Plots(
+				g.BarOf("Volume", stockVolume).XValues(stockDates).Width(12*60*60).SetPlotYAxis(g.ImPlotYAxisFirstOnRight).Fill(0.3),
+				g.Candlestick("ACME", stockDates, stockOpen, stockClose, stockLow, stockHigh),
+				g.OHLC("ACME (OHLC)", stockDates, stockOpen, stockClose, stockLow, stockHigh).Width(0.3),
+			),
 		g.Row(
 			g.Style().To(
 				g.Plot("Plot Bars").
@@ -64,6 +125,69 @@ func loop() {
 					).SetPlotColor(g.StylePlotColorPlotBg, colornames.Pink),
 				),
 		),
+		g.Plot("Interactive (drag lines and point, right-drag to select)").
+			Size(-1, 250).
+			AxisLimits(0, 10, -1.5, 1.5, g.ConditionOnce).
+			OnSelect(func(r g.PlotRect) {
+				selection = fmt.Sprintf("selected x: %.2f - %.2f", r.XMin, r.XMax)
+			}).
+			Plots(
+				g.Line("sin", linedata).XScale(0.1).OnHover(func(_ int, x, y float64) {
+					hovered = fmt.Sprintf("nearest point: (%.2f, %.2f)", x, y)
+				}),
+				g.DragLineX(&cursor1).Color(colornames.Orange),
+				g.DragLineX(&cursor2).Color(colornames.Orange),
+				g.TagX(cursor1, ""),
+				g.TagX(cursor2, ""),
+				g.DragPoint(&peakX, &peakY).Color(colornames.Red),
+				g.Annotation(peakX, peakY, "peak").PixelOffset(10, -10).Clamp(true),
+			),
+		g.Labelf("Δx = %.2f; %s; %s", math.Abs(cursor2-cursor1), hovered, selection),
+		g.Subplots(1, 2).
+			Title("Subplots with linked axes").
+			Size(-1, 200).
+			Flags(g.PlotSubplotFlagsLinkAllX|g.PlotSubplotFlagsLinkAllY).
+			ColRatios(2, 1).
+			Plots(
+				g.Plot("sin").Plots(g.Line("sin", linedata)),
+				g.Plot("cos").Plots(g.Line("cos", linedata2)),
+			),
+		g.Row(
+			g.Plot("Linked 1").Size(400, 150).LinkXAxis(g.AxisX1, linkedX).Plots(g.Line("sin", linedata)),
+			g.Plot("Linked 2").Size(400, 150).LinkXAxis(g.AxisX1, linkedX).Plots(g.Line("cos", linedata2)),
+		),
+		g.Plot("Streaming").
+			Size(-1, 150).
+			XAxeFlags(g.PlotAxisFlagsAutoFit).
+			AxisLimits(0, 0, -1.5, 1.5, g.ConditionOnce).
+			SetAxisFormatter(g.AxisX1, g.PlotFormatDuration(time.Second)).
+			SetAxisFormatter(g.AxisY1, g.PlotFormatSI("V", 1)).
+			Plots(
+				streamData.LineXY("Signal"),
+			),
+		g.Row(
+			g.Plot("Histogram & Stats").
+				Size(500, 250).
+				AxisLimits(-4, 4, 0, 0.5, g.ConditionOnce).
+				SetAxisFormatter(g.AxisY1, g.PlotFormatPercent(0)).
+				Plots(
+					g.Histogram("Normal distribution", histdata).Bins(50).Density(true),
+					g.InfLines("Mean", []float64{0}),
+					g.ErrorBars("Measurements", errXs, errYs, errs),
+					g.Stems("Stems", errYs).XScale(1).X0(-3.5),
+					g.PlotText("σ = 1", 1, 0.3),
+				),
+			g.Plot("Heatmap").
+				Flags(g.PlotFlagsNoLegend).
+				Size(250, 250).
+				XAxeFlags(g.PlotAxisFlagsNoDecorations).
+				YAxeFlags(g.PlotAxisFlagsNoDecorations, 0, 0).
+				AxisLimits(0, 1, 0, 1, g.ConditionAlways).
+				Plots(
+					g.Heatmap("Heatmap", heatmapdata, 5, 5).Scale(0, 1).Colormap(g.PlotColormapViridis),
+				),
+			g.ColormapScale("##heatmapScale", 0, 1).Size(0, 250).Colormap(g.PlotColormapViridis),
+		),
 	)
 }
 
@@ -76,6 +200,10 @@ func main() {
 		scatterdata = append(scatterdata, math.Sin(x)+0.1)
 	}
 
+	for i := 0; i < 10; i++ {
+		samples = append(samples, int16(rand.Intn(3)-1))
+	}
+
 	for i := 0; i < 100; i += 5 {
 		lineTicks = append(lineTicks, g.PlotTicker{Position: float64(i), Label: fmt.Sprintf("P%d", i)})
 	}
@@ -89,13 +217,50 @@ func main() {
 	}
 
 	for i := 0; i < 100; i++ {
-		timeDataX = append(timeDataX, float64(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Hour*time.Duration(24*i)).Unix()))
+		timeDataX = append(timeDataX, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Hour*time.Duration(24*i)))
 		timeDataY = append(timeDataY, rand.Float64())
 		timeScatterY = append(timeScatterY, rand.Float64())
 	}
 
-	timeDataMin = timeDataX[0]
-	timeDataMax = timeDataX[len(timeDataX)-1]
+	for i := 0; i < 10000; i++ {
+		histdata = append(histdata, rand.NormFloat64())
+	}
+
+	for i := 0; i < 25; i++ {
+		heatmapdata = append(heatmapdata, rand.Float64())
+	}
+
+	for x := -3.0; x <= 3; x++ {
+		errXs = append(errXs, x)
+		errYs = append(errYs, math.Exp(-x*x/2)/math.Sqrt(2*math.Pi))
+		errs = append(errs, 0.02+rand.Float64()*0.03)
+	}
+
+	price := 100.0
+
+	for i := 0; i < 60; i++ {
+		open := price
+		price += rand.NormFloat64() * 2
+		stockDates = append(stockDates, g.TimeToPlotValue(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i)))
+		stockOpen = append(stockOpen, open)
+		stockClose = append(stockClose, price)
+		stockLow = append(stockLow, math.Min(open, price)-rand.Float64()*2)
+		stockHigh = append(stockHigh, math.Max(open, price)+rand.Float64()*2)
+		stockVolume = append(stockVolume, 500+rand.Float64()*1000)
+	}
+
+	timeDataMin = g.TimeToPlotValue(timeDataX[0])
+	timeDataMax = g.TimeToPlotValue(timeDataX[len(timeDataX)-1])
+
+	go func() {
+		start := time.Now()
+
+		for range time.Tick(10 * time.Millisecond) {
+			t := time.Since(start).Seconds()
+			streamData.Add(t, math.Sin(t*3)+rand.Float64()*0.2)
+			g.Update()
+		}
+	}()
 
 	wnd := g.NewMasterWindow("Plot Demo", 1000, 900, 0)
 	wnd.Run(loop)
@@ -1,42 +1,96 @@
-// Package main demonstrates use of TableFlagsSortable and Sort function.
+// Package main demonstrates use of TableFlagsSortable, Sort function and multi-column sorting with OnSort.
+// It also shows saving of the columns layout and exporting the table to CSV (or to clipboard with Ctrl+C).
 package main
 
 import (
+	"cmp"
 	"fmt"
-	"sort"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/AllenDang/giu"
 )
 
+type row struct {
+	name string
+	size int
+}
+
 var (
-	data = []string{"A", "AA", "ABC", "CBA", "BBB"}
-	cols = []*giu.TableRowWidget{}
+	data   = []row{{"A", 3}, {"AA", 1}, {"ABC", 3}, {"CBA", 2}, {"BBB", 1}}
+	cols   = []*giu.TableRowWidget{}
+	layout []byte
 )
 
 func rebuildColumns() {
 	cols = make([]*giu.TableRowWidget, 0)
 	for _, d := range data {
-		cols = append(cols, giu.TableRow(giu.Label(d)))
+		cols = append(cols, giu.TableRow(giu.Label(d.name), giu.Labelf("%d", d.size)))
 	}
 }
 
+// sortData sorts data by all sorted columns (hold Shift when clicking headers).
+func sortData(specs []giu.ColumnSortSpec) {
+	slices.SortStableFunc(data, func(a, b row) int {
+		for _, spec := range specs {
+			var result int
+
+			switch spec.ColumnIndex {
+			case 0:
+				result = strings.Compare(a.name, b.name)
+			case 1:
+				result = cmp.Compare(a.size, b.size)
+			}
+
+			if spec.Direction == giu.SortDescending {
+				result = -result
+			}
+
+			if result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	})
+
+	rebuildColumns()
+}
+
 func loop() {
-	giu.SingleWindow().Layout(
-		giu.Table().Flags(giu.TableFlagsSortable|giu.TableFlagsResizable).Columns(
+	table := giu.Table().ID("sortable").
+		Flags(giu.TableFlagsSortable|giu.TableFlagsSortMulti|giu.TableFlagsResizable|giu.TableFlagsReorderable|giu.TableFlagsHideable).
+		HeaderContextMenu(true).
+		ClipboardCopy(true).
+		Columns(
 			giu.TableColumn("Col 1").Sort(func(s giu.SortDirection) {
 				fmt.Println("sorting col 1", s)
+			}),
+			giu.TableColumn("Col 2"),
+		).OnSort(sortData).Rows(cols...)
 
-				switch s {
-				case giu.SortAscending:
-					sort.Strings(data)
-				case giu.SortDescending:
-					sort.Sort(sort.Reverse(sort.StringSlice(data)))
+	giu.SingleWindow().Layout(
+		giu.Row(
+			giu.Button("Save layout").OnClick(func() {
+				var err error
+				if layout, err = table.SaveLayout(); err != nil {
+					fmt.Println(err)
 				}
-
-				rebuildColumns()
 			}),
-			giu.TableColumn("Col 2"),
-		).Rows(cols...),
+			giu.Button("Load layout").Disabled(layout == nil).OnClick(func() {
+				if err := table.LoadLayout(layout); err != nil {
+					fmt.Println(err)
+				}
+			}),
+			giu.Button("Export CSV").OnClick(func() {
+				if err := table.Export(os.Stdout, giu.TableExportCSV); err != nil {
+					fmt.Println(err)
+				}
+			}),
+			giu.Label(string(layout)),
+		),
+		table,
 	)
 }
 
@@ -6,6 +6,8 @@ import (
 	"image/color"
 	"time"
 
+	"golang.org/x/image/colornames"
+
 	g "github.com/AllenDang/giu"
 )
 
@@ -59,6 +61,19 @@ func loop() {
 		),
 		g.Label("One line label"),
 		g.Label("Auto wrapped label with very long line...............................................this line should be wrapped.").Wrapped(true),
+		g.RichText(
+			g.Span("Rich text consists of spans with "),
+			g.Span("different colors").Color(colornames.Orange),
+			g.Span(", "),
+			g.Span("highlights").Background(colornames.Darkslateblue),
+			g.Span(", "),
+			g.Span("underlined").Underline(),
+			g.Span(" or "),
+			g.Span("crossed out").Strikethrough(),
+			g.Span(" words and "),
+			g.Span("links").OnClick(func() { g.OpenURL("https://github.com/AllenDang/giu") }).Tooltip("Open giu repository"),
+			g.Span(". Select it with mouse and press Ctrl+C to copy."),
+		),
 		g.Link("I'm a link! Click me!").OnClick(func() { fmt.Println("Link clicked a") }),
 		g.Label("right/left click me"),
 		g.Event().
@@ -14,6 +14,7 @@ require (
 	github.com/pkg/browser v0.0.0-20210911075715-681adbf594b8
 	github.com/sahilm/fuzzy v0.1.1
 	github.com/stretchr/testify v1.11.1
+	github.com/yuin/goldmark v1.8.2
 	golang.design/x/hotkey v0.4.1
 	golang.org/x/image v0.32.0
 	gopkg.in/eapache/queue.v1 v1.1.0